@@ -0,0 +1,155 @@
+package godata
+
+import (
+	"github.com/tkhandel/go-data/log"
+	"math"
+)
+
+// The arithmetic below works element-wise on two series of the same length, or on a series and a scalar. A null
+// on either side gives a null. Add, Sub, Mul and Mod keep the type of their operands, which must match, while
+// their Float variants promote ints to floats so that ints and floats can be mixed, e.g. MulFloat(price, qty).
+// Div and Pow always give floats.
+
+func Add[T Number](x, y Series[T]) (Series[T], error) {
+	return combine(x, y, func(a, b T) (T, bool) { return a + b, true })
+}
+
+func Sub[T Number](x, y Series[T]) (Series[T], error) {
+	return combine(x, y, func(a, b T) (T, bool) { return a - b, true })
+}
+
+func Mul[T Number](x, y Series[T]) (Series[T], error) {
+	return combine(x, y, func(a, b T) (T, bool) { return a * b, true })
+}
+
+// Div always divides as floats, so dividing ints does not truncate and dividing by zero gives an infinity or NaN.
+func Div[T, U Number](x Series[T], y Series[U]) (FloatSeries, error) {
+	return combine(AsFloat(x), AsFloat(y), func(a, b float64) (float64, bool) { return a / b, true })
+}
+
+// Mod returns the remainder of x divided by y, with the sign of x. An int remainder by zero is null.
+func Mod[T Number](x, y Series[T]) (Series[T], error) {
+	return combine(x, y, mod[T])
+}
+
+func Pow[T, U Number](x Series[T], y Series[U]) (FloatSeries, error) {
+	return combine(AsFloat(x), AsFloat(y), func(a, b float64) (float64, bool) { return math.Pow(a, b), true })
+}
+
+func AddFloat[T, U Number](x Series[T], y Series[U]) (FloatSeries, error) {
+	return Add(AsFloat(x), AsFloat(y))
+}
+
+func SubFloat[T, U Number](x Series[T], y Series[U]) (FloatSeries, error) {
+	return Sub(AsFloat(x), AsFloat(y))
+}
+
+func MulFloat[T, U Number](x Series[T], y Series[U]) (FloatSeries, error) {
+	return Mul(AsFloat(x), AsFloat(y))
+}
+
+func ModFloat[T, U Number](x Series[T], y Series[U]) (FloatSeries, error) {
+	return Mod(AsFloat(x), AsFloat(y))
+}
+
+func AddScalar[T Number](s Series[T], value T) Series[T] {
+	return s.Apply(func(entry T) T { return entry + value })
+}
+
+func SubScalar[T Number](s Series[T], value T) Series[T] {
+	return s.Apply(func(entry T) T { return entry - value })
+}
+
+func MulScalar[T Number](s Series[T], value T) Series[T] {
+	return s.Apply(func(entry T) T { return entry * value })
+}
+
+func DivScalar[T, U Number](s Series[T], value U) FloatSeries {
+	return AsFloat(s).Apply(func(entry float64) float64 { return entry / float64(value) })
+}
+
+func ModScalar[T Number](s Series[T], value T) Series[T] {
+	return mapValues(s, func(entry T) (T, bool) { return mod(entry, value) })
+}
+
+func PowScalar[T, U Number](s Series[T], value U) FloatSeries {
+	return AsFloat(s).Apply(func(entry float64) float64 { return math.Pow(entry, float64(value)) })
+}
+
+func AddFloatScalar[T Number](s Series[T], value float64) FloatSeries {
+	return AddScalar(AsFloat(s), value)
+}
+
+func SubFloatScalar[T Number](s Series[T], value float64) FloatSeries {
+	return SubScalar(AsFloat(s), value)
+}
+
+func MulFloatScalar[T Number](s Series[T], value float64) FloatSeries {
+	return MulScalar(AsFloat(s), value)
+}
+
+func ModFloatScalar[T Number](s Series[T], value float64) FloatSeries {
+	return ModScalar(AsFloat(s), value)
+}
+
+// AsFloat converts the values to floats, keeping the nulls.
+func AsFloat[T Number](s Series[T]) FloatSeries {
+	return mapValues(s, func(entry T) (float64, bool) { return float64(entry), true })
+}
+
+func mod[T Number](a, b T) (T, bool) {
+	if isFloat[T]() {
+		return T(math.Mod(float64(a), float64(b))), true
+	}
+	if b == 0 {
+		return 0, false
+	}
+	// a % b is not defined on every Number, but integer division truncates the same way
+	return a - b*(a/b), true
+}
+
+func isFloat[T Number]() bool {
+	return T(1)/T(2) != 0
+}
+
+// combine applies op to the values at the same position in x and y. op reports false for a null result.
+func combine[T, R any](x, y Series[T], op func(a, b T) (R, bool)) (Series[R], error) {
+	if x.Size() != y.Size() {
+		err := LengthMismatch{What: "series", Expected: x.Size(), Actual: y.Size()}
+		log.Get().Error(err.Error())
+		return Series[R]{}, err
+	}
+
+	out := Series[R]{}
+	if x.Size() > 0 {
+		out.data = make([]R, x.Size())
+	}
+	bits := bitmapBuilder{}
+	for k := range x.data {
+		valid := x.Valid(k) && y.Valid(k)
+		if valid {
+			out.data[k], valid = op(x.data[k], y.data[k])
+		}
+		bits.append(valid)
+	}
+	out.valid = bits.bitmap()
+	return out, nil
+}
+
+// mapValues applies op to each value of s. op reports false for a null result.
+func mapValues[T, R any](s Series[T], op func(T) (R, bool)) Series[R] {
+	out := Series[R]{}
+	if s.Size() > 0 {
+		out.data = make([]R, s.Size())
+	}
+	bits := bitmapBuilder{}
+	for k, entry := range s.data {
+		valid := s.Valid(k)
+		if valid {
+			out.data[k], valid = op(entry)
+		}
+		bits.append(valid)
+	}
+	out.valid = bits.bitmap()
+	return out
+}
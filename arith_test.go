@@ -0,0 +1,90 @@
+package godata
+
+import (
+	"github.com/stretchr/testify/require"
+	"math"
+	"testing"
+)
+
+func TestArithmetic(t *testing.T) {
+	x := NewIntSeries(7, -7, 4).AppendNull()
+	y := NewIntSeries(2, 2, 0, 1)
+
+	sum, err := Add(x, y)
+	require.NoError(t, err)
+	require.Equal(t, NewIntSeries(9, -5, 4).AppendNull(), sum)
+
+	diff, err := Sub(x, y)
+	require.NoError(t, err)
+	require.Equal(t, NewIntSeries(5, -9, 4).AppendNull(), diff)
+
+	product, err := Mul(x, y)
+	require.NoError(t, err)
+	require.Equal(t, NewIntSeries(14, -14, 0).AppendNull(), product)
+
+	quotient, err := Div(x, y)
+	require.NoError(t, err)
+	require.Equal(t, NewFloatSeries(3.5, -3.5, math.Inf(1)).AppendNull(), quotient)
+
+	remainder, err := Mod(x, y)
+	require.NoError(t, err)
+	require.Equal(t, TruthFilter{false, false, true, true}, remainder.IsNull())
+	require.Equal(t, []int64{1, -1}, remainder.data[:2])
+
+	power, err := Pow(x, y)
+	require.NoError(t, err)
+	require.Equal(t, NewFloatSeries(49, 49, 1).AppendNull(), power)
+
+	_, err = Add(x, NewIntSeries(1))
+	require.IsType(t, LengthMismatch{}, err)
+}
+
+func TestArithmetic_Scalar(t *testing.T) {
+	price := NewFloatSeries(1.5, 2).AppendNull()
+	qty := NewIntSeries(2, 3, 4)
+
+	revenue, err := MulFloat(price, qty)
+	require.NoError(t, err)
+	require.Equal(t, NewFloatSeries(3, 6).AppendNull(), revenue)
+
+	require.Equal(t, NewFloatSeries(2.5, 3).AppendNull(), AddScalar(price, 1))
+	require.Equal(t, NewIntSeries(1, 2, 3), SubScalar(qty, 1))
+	require.Equal(t, NewIntSeries(20, 30, 40), MulScalar(qty, 10))
+	require.Equal(t, NewFloatSeries(1, 1.5, 2), DivScalar(qty, 2))
+	require.Equal(t, NewFloatSeries(1.5, 0).AppendNull(), ModScalar(price, 2))
+	require.Equal(t, TruthFilter{true, true, true}, ModScalar(qty, 0).IsNull())
+	require.Equal(t, NewFloatSeries(4, 9, 16), PowScalar(qty, 2))
+	require.Equal(t, NewFloatSeries(2.5, 3.5, 4.5), AddFloatScalar(qty, 0.5))
+	require.Equal(t, NewFloatSeries(1.5, 2.5, 3.5), SubFloatScalar(qty, 0.5))
+	require.Equal(t, NewFloatSeries(3, 4.5, 6), MulFloatScalar(qty, 1.5))
+	require.Equal(t, NewFloatSeries(0.5, 0, 1), ModFloatScalar(qty, 1.5))
+	require.Equal(t, NewFloatSeries(4, 6, 8), DivScalar(qty, 0.5))
+}
+
+func TestArithmetic_Mixed(t *testing.T) {
+	price := NewFloatSeries(1.5, 2).AppendNull()
+	qty := NewIntSeries(2, 3, 4)
+
+	sum, err := AddFloat(qty, price)
+	require.NoError(t, err)
+	require.Equal(t, NewFloatSeries(3.5, 5).AppendNull(), sum)
+
+	diff, err := SubFloat(price, qty)
+	require.NoError(t, err)
+	require.Equal(t, NewFloatSeries(-0.5, -1).AppendNull(), diff)
+
+	remainder, err := ModFloat(qty, price)
+	require.NoError(t, err)
+	require.Equal(t, NewFloatSeries(0.5, 1).AppendNull(), remainder)
+
+	quotient, err := Div(qty, price)
+	require.NoError(t, err)
+	require.Equal(t, NewFloatSeries(4.0/3, 1.5).AppendNull(), quotient)
+
+	power, err := Pow(price, qty)
+	require.NoError(t, err)
+	require.Equal(t, NewFloatSeries(2.25, 8).AppendNull(), power)
+
+	_, err = MulFloat(qty, NewFloatSeries(1))
+	require.IsType(t, LengthMismatch{}, err)
+}
@@ -0,0 +1,283 @@
+package godata
+
+import (
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/bitutil"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/pkg/errors"
+	"github.com/tkhandel/go-data/log"
+	"io"
+	"math"
+	"time"
+)
+
+// arrowTimestamp is the Arrow type of time columns, which are converted to UTC.
+var arrowTimestamp = &arrow.TimestampType{Unit: arrow.Nanosecond, TimeZone: "UTC"}
+
+// minArrowTime and maxArrowTime bound the times that fit in an arrowTimestamp, from 1677 to 2262.
+var (
+	minArrowTime = time.Unix(0, math.MinInt64)
+	maxArrowTime = time.Unix(0, math.MaxInt64)
+)
+
+// ToArrow returns the frame as an Arrow record with a field per column, in the order of the frame. Ints and
+// floats become int64 and float64 arrays sharing the memory of the series and their validity bitmap, so the frame
+// must not be changed while the record is in use. Strings, bools and times are copied into utf8, boolean and UTC
+// timestamp arrays, and times outside of the range of nanosecond timestamps give an error.
+func (df DataFrame) ToArrow() (arrow.Record, error) {
+	names := df.ColumnNames()
+	fields := make([]arrow.Field, len(names))
+	columns := make([]arrow.Array, len(names))
+	rows := df.NRows()
+	defer func() {
+		for _, column := range columns {
+			if column != nil {
+				column.Release()
+			}
+		}
+	}()
+
+	for j, name := range names {
+		if df.columnSize(name) != rows {
+			err := LengthMismatch{What: "column " + name, Expected: rows, Actual: df.columnSize(name)}
+			log.Get().Error(err.Error())
+			return nil, err
+		}
+
+		switch series := df.series[name].(type) {
+		case IntSeries:
+			columns[j] = array.MakeFromData(sharedArrowData(arrow.PrimitiveTypes.Int64, series,
+				arrow.Int64Traits.CastToBytes(series.data)))
+		case FloatSeries:
+			columns[j] = array.MakeFromData(sharedArrowData(arrow.PrimitiveTypes.Float64, series,
+				arrow.Float64Traits.CastToBytes(series.data)))
+		case StringSeries:
+			builder := array.NewStringBuilder(memory.DefaultAllocator)
+			buildArrow(series, builder, builder.Append)
+			columns[j] = builder.NewArray()
+		case BoolSeries:
+			builder := array.NewBooleanBuilder(memory.DefaultAllocator)
+			buildArrow(series, builder, builder.Append)
+			columns[j] = builder.NewArray()
+		case TimeSeries:
+			for x, val := range series.data {
+				if series.Valid(x) && (val.Before(minArrowTime) || val.After(maxArrowTime)) {
+					err := errors.Errorf("row %d: %s is out of the range of nanosecond timestamps", x,
+						val.Format(time.RFC3339Nano))
+					return nil, arrowError(err, "converting column "+name)
+				}
+			}
+			builder := array.NewTimestampBuilder(memory.DefaultAllocator, arrowTimestamp)
+			buildArrow(series, builder, func(val time.Time) { builder.Append(arrow.Timestamp(val.UnixNano())) })
+			columns[j] = builder.NewArray()
+		}
+		fields[j] = arrow.Field{Name: name, Type: columns[j].DataType(), Nullable: true}
+	}
+	return array.NewRecord(arrow.NewSchema(fields, nil), columns, int64(rows)), nil
+}
+
+// sharedArrowData lays out the values of series, whose bytes are data, as an Arrow array without copying them.
+func sharedArrowData[T any](dType arrow.DataType, series Series[T], data []byte) arrow.ArrayData {
+	var validity *memory.Buffer
+	if series.valid != nil {
+		validity = memory.NewBufferBytes(series.valid)
+	}
+	buffers := []*memory.Buffer{validity, memory.NewBufferBytes(data)}
+	return array.NewData(dType, series.Size(), buffers, nil, series.nullCount(), 0)
+}
+
+func buildArrow[T any](series Series[T], builder array.Builder, appendValue func(T)) {
+	builder.Reserve(series.Size())
+	for x, entry := range series.data {
+		if series.Valid(x) {
+			appendValue(entry)
+		} else {
+			builder.AppendNull()
+		}
+	}
+}
+
+// FromArrowRecord returns a frame with a column per field of the record. Int64 and float64 arrays become int and
+// float series sharing the memory of the arrays, so the record must not be released while the frame is in use
+// unless its memory comes from the Go allocator. Other int and float arrays are converted, utf8, large utf8 and
+// binary arrays become strings, boolean arrays bools, and timestamp and date arrays times, in the location of the
+// timestamp type or UTC.
+func FromArrowRecord(rec arrow.Record) (DataFrame, error) {
+	df := DataFrame{columns: make(map[string]Column), series: make(map[string]AnySeries)}
+	var err error
+	for j, field := range rec.Schema().Fields() {
+		if err := checkArrowRange(rec.Column(j)); err != nil {
+			return DataFrame{}, arrowError(err, "reading column "+field.Name)
+		}
+		series, ok := fromArrowArray(rec.Column(j))
+		if !ok {
+			err := Unsupported{What: "Arrow type of column " + field.Name, Value: field.Type.String()}
+			log.Get().Error(err.Error())
+			return DataFrame{}, err
+		}
+		if _, ok := df.columns[field.Name]; ok {
+			err := Duplicate{What: "column", Value: field.Name}
+			log.Get().Error(err.Error())
+			return DataFrame{}, err
+		}
+		if df, err = df.setSeries(field.Name, series); err != nil {
+			return DataFrame{}, err
+		}
+	}
+	return df, nil
+}
+
+// checkArrowRange reports an error for the first value of arr that does not fit in an int64.
+func checkArrowRange(arr arrow.Array) error {
+	if arr, ok := arr.(*array.Uint64); ok {
+		for i := 0; i < arr.Len(); i++ {
+			if arr.IsValid(i) && arr.Value(i) > math.MaxInt64 {
+				return errors.Errorf("row %d: %d overflows int64", i, arr.Value(i))
+			}
+		}
+	}
+	return nil
+}
+
+func fromArrowArray(arr arrow.Array) (AnySeries, bool) {
+	switch arr := arr.(type) {
+	case *array.Int64:
+		return IntSeries{data: arr.Int64Values(), valid: arrowValidity(arr)}, true
+	case *array.Float64:
+		return FloatSeries{data: arr.Float64Values(), valid: arrowValidity(arr)}, true
+	case *array.Int8:
+		return convertArrow(arr, func(i int) int64 { return int64(arr.Value(i)) }), true
+	case *array.Int16:
+		return convertArrow(arr, func(i int) int64 { return int64(arr.Value(i)) }), true
+	case *array.Int32:
+		return convertArrow(arr, func(i int) int64 { return int64(arr.Value(i)) }), true
+	case *array.Uint8:
+		return convertArrow(arr, func(i int) int64 { return int64(arr.Value(i)) }), true
+	case *array.Uint16:
+		return convertArrow(arr, func(i int) int64 { return int64(arr.Value(i)) }), true
+	case *array.Uint32:
+		return convertArrow(arr, func(i int) int64 { return int64(arr.Value(i)) }), true
+	case *array.Uint64:
+		return convertArrow(arr, func(i int) int64 { return int64(arr.Value(i)) }), true
+	case *array.Float32:
+		return convertArrow(arr, func(i int) float64 { return float64(arr.Value(i)) }), true
+	case *array.String:
+		return convertArrow(arr, arr.Value), true
+	case *array.LargeString:
+		return convertArrow(arr, arr.Value), true
+	case *array.Binary:
+		return convertArrow(arr, arr.ValueString), true
+	case *array.Boolean:
+		return convertArrow(arr, arr.Value), true
+	case *array.Timestamp:
+		timestampType := arr.DataType().(*arrow.TimestampType)
+		loc, err := timestampType.GetZone()
+		if err != nil || loc == nil {
+			loc = time.UTC
+		}
+		return convertArrow(arr, func(i int) time.Time { return arr.Value(i).ToTime(timestampType.Unit).In(loc) }), true
+	case *array.Date32:
+		return convertArrow(arr, func(i int) time.Time { return arr.Value(i).ToTime() }), true
+	case *array.Date64:
+		return convertArrow(arr, func(i int) time.Time { return arr.Value(i).ToTime() }), true
+	}
+	return nil, false
+}
+
+// arrowValidity returns the validity bitmap of the array, sharing its memory unless the array is a slice
+// starting within a byte.
+func arrowValidity(arr arrow.Array) bitmap {
+	if arr.NullN() == 0 {
+		return nil
+	}
+	offset := arr.Data().Offset()
+	if offset%8 == 0 {
+		return arr.NullBitmapBytes()[offset/8 : bitutil.BytesForBits(int64(offset+arr.Len()))]
+	}
+	bits := bitmapBuilder{}
+	for i := 0; i < arr.Len(); i++ {
+		bits.append(arr.IsValid(i))
+	}
+	return bits.bitmap()
+}
+
+func convertArrow[T any](arr arrow.Array, value func(i int) T) Series[T] {
+	series := Series[T]{data: make([]T, arr.Len())}
+	bits := bitmapBuilder{}
+	for i := range series.data {
+		if arr.IsValid(i) {
+			series.data[i] = value(i)
+		}
+		bits.append(arr.IsValid(i))
+	}
+	series.valid = bits.bitmap()
+	return series
+}
+
+// LoadArrowStream reads the record batches of an Arrow IPC stream into a single frame.
+func LoadArrowStream(rdr io.Reader) (DataFrame, error) {
+	stream, err := ipc.NewReader(rdr)
+	if err != nil {
+		return DataFrame{}, arrowError(err, "reading Arrow stream")
+	}
+	defer stream.Release()
+	return loadArrowRecords(stream, "reading Arrow stream")
+}
+
+// loadArrowRecords reads every record of records into a single frame.
+func loadArrowRecords(records array.RecordReader, what string) (DataFrame, error) {
+	df, err := FromArrowRecord(array.NewRecord(records.Schema(), emptyArrowColumns(records.Schema()), 0))
+	if err != nil {
+		return DataFrame{}, err
+	}
+	for records.Next() {
+		batch, err := FromArrowRecord(records.Record())
+		if err != nil {
+			return DataFrame{}, err
+		}
+		// concatenating copies the values, which the reader may reuse for the next record
+		for name, series := range batch.series {
+			df.series[name] = df.series[name].concatAny(series)
+		}
+	}
+	// some readers report the end of the records as an error
+	if err := records.Err(); err != nil && err != io.EOF {
+		return DataFrame{}, arrowError(err, what)
+	}
+	return df, nil
+}
+
+func emptyArrowColumns(schema *arrow.Schema) []arrow.Array {
+	columns := make([]arrow.Array, schema.NumFields())
+	for j, field := range schema.Fields() {
+		columns[j] = array.MakeArrayOfNull(memory.DefaultAllocator, field.Type, 0)
+	}
+	return columns
+}
+
+// WriteArrowStream writes the frame as an Arrow IPC stream holding a single record batch.
+func (df DataFrame) WriteArrowStream(wrt io.Writer) error {
+	rec, err := df.ToArrow()
+	if err != nil {
+		return err
+	}
+	defer rec.Release()
+
+	stream := ipc.NewWriter(wrt, ipc.WithSchema(rec.Schema()))
+	if err := stream.Write(rec); err != nil {
+		stream.Close()
+		return arrowError(err, "writing Arrow stream")
+	}
+	if err := stream.Close(); err != nil {
+		return arrowError(err, "writing Arrow stream")
+	}
+	return nil
+}
+
+func arrowError(err error, what string) error {
+	arrowErr := ProcessingError{Err: errors.Wrap(err, what)}
+	log.Get().Error(arrowErr.Error())
+	return arrowErr
+}
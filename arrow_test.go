@@ -0,0 +1,127 @@
+package godata
+
+import (
+	"bytes"
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/stretchr/testify/require"
+	"math"
+	"testing"
+	"time"
+)
+
+func testArrowDF(t *testing.T) DataFrame {
+	df, err := NewDataFrame(NewStringColumn("name"), NewIntColumn("qty"), NewFloatColumn("price"),
+		NewBoolColumn("fresh"), NewTimeColumn("picked"))
+	require.NoError(t, err)
+	picked := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	df, _ = df.SetStringColumn("name", NewStringSeries("apple", "pear").AppendNull())
+	df, _ = df.SetIntColumn("qty", NewIntSeries(3, 4).AppendNull())
+	df, _ = df.SetFloatColumn("price", NewFloatSeries(1.5, 2, 0.25))
+	df, _ = df.SetBoolColumn("fresh", NewBoolSeries(true).AppendNull().Concat(NewBoolSeries(false)))
+	df, _ = df.SetTimeColumn("picked", NewTimeSeries(picked, picked.Add(time.Hour)).AppendNull())
+	return df
+}
+
+func TestDataFrame_ToArrow(t *testing.T) {
+	df := testArrowDF(t)
+	rec, err := df.ToArrow()
+	require.NoError(t, err)
+	defer rec.Release()
+
+	require.Equal(t, int64(3), rec.NumRows())
+	require.Equal(t, "schema:\n  fields: 5\n"+
+		"    - name: type=utf8, nullable\n"+
+		"    - qty: type=int64, nullable\n"+
+		"    - price: type=float64, nullable\n"+
+		"    - fresh: type=bool, nullable\n"+
+		"    - picked: type=timestamp[ns, tz=UTC], nullable", rec.Schema().String())
+
+	qty := rec.Column(1).(*array.Int64)
+	require.Equal(t, 1, qty.NullN())
+	require.True(t, qty.IsNull(2))
+	require.Equal(t, []int64{3, 4, 0}, qty.Int64Values())
+	require.Same(t, &df.series["qty"].(IntSeries).data[0], &qty.Int64Values()[0])
+
+	loaded, err := FromArrowRecord(rec)
+	require.NoError(t, err)
+	require.Equal(t, df, loaded)
+	require.Same(t, &qty.Int64Values()[0], &loaded.series["qty"].(IntSeries).data[0])
+}
+
+func TestFromArrowRecord(t *testing.T) {
+	mem := memory.NewGoAllocator()
+	ints := array.NewInt32Builder(mem)
+	ints.AppendValues([]int32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, []bool{true, true, true, true, true, true, true, true, false, true})
+	floats := array.NewFloat64Builder(mem)
+	floats.AppendValues([]float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, []bool{true, true, true, true, true, true, true, true, false, true})
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "small", Type: arrow.PrimitiveTypes.Int32},
+		{Name: "value", Type: arrow.PrimitiveTypes.Float64},
+	}, nil)
+	rec := array.NewRecord(schema, []arrow.Array{ints.NewArray(), floats.NewArray()}, 10)
+	defer rec.Release()
+
+	df, err := FromArrowRecord(rec.NewSlice(8, 10))
+	require.NoError(t, err)
+	small, err := df.IntColumn("small")
+	require.NoError(t, err)
+	require.Equal(t, NewIntSeries().AppendNull().Concat(NewIntSeries(10)), small)
+	value, err := df.FloatColumn("value")
+	require.NoError(t, err)
+	require.Equal(t, TruthFilter{true, false}, value.IsNull())
+	require.Equal(t, 10.0, value.Index(1))
+
+	list := array.NewListBuilder(mem, arrow.PrimitiveTypes.Int64)
+	list.AppendNull()
+	schema = arrow.NewSchema([]arrow.Field{{Name: "list", Type: arrow.ListOf(arrow.PrimitiveTypes.Int64)}}, nil)
+	_, err = FromArrowRecord(array.NewRecord(schema, []arrow.Array{list.NewArray()}, 1))
+	require.IsType(t, Unsupported{}, err)
+}
+
+func TestArrow_OutOfRange(t *testing.T) {
+	df, err := NewDataFrame(NewTimeColumn("at"))
+	require.NoError(t, err)
+	df, err = df.SetTimeColumn("at", NewTimeSeries(time.Date(2262, 1, 1, 0, 0, 0, 0, time.UTC), time.Time{}))
+	require.NoError(t, err)
+	_, err = df.ToArrow()
+	require.IsType(t, ProcessingError{}, err)
+	require.Contains(t, err.Error(), "converting column at: row 1")
+
+	uints := array.NewUint64Builder(memory.NewGoAllocator())
+	uints.AppendValues([]uint64{math.MaxInt64, 1 << 63}, nil)
+	schema := arrow.NewSchema([]arrow.Field{{Name: "id", Type: arrow.PrimitiveTypes.Uint64}}, nil)
+	rec := array.NewRecord(schema, []arrow.Array{uints.NewArray()}, 2)
+	defer rec.Release()
+	_, err = FromArrowRecord(rec)
+	require.IsType(t, ProcessingError{}, err)
+	require.Contains(t, err.Error(), "reading column id: row 1")
+
+	loaded, err := FromArrowRecord(rec.NewSlice(0, 1))
+	require.NoError(t, err)
+	ids, err := loaded.IntColumn("id")
+	require.NoError(t, err)
+	require.Equal(t, NewIntSeries(math.MaxInt64), ids)
+}
+
+func TestArrowStream(t *testing.T) {
+	df := testArrowDF(t)
+	var out bytes.Buffer
+	require.NoError(t, df.WriteArrowStream(&out))
+
+	loaded, err := LoadArrowStream(&out)
+	require.NoError(t, err)
+	require.Equal(t, df, loaded)
+
+	empty, err := NewDataFrame(NewIntColumn("qty"))
+	require.NoError(t, err)
+	require.NoError(t, empty.WriteArrowStream(&out))
+	loaded, err = LoadArrowStream(&out)
+	require.NoError(t, err)
+	require.Equal(t, []Column{NewIntColumn("qty")}, loaded.Columns())
+	require.Equal(t, 0, loaded.NRows())
+
+	_, err = LoadArrowStream(bytes.NewReader([]byte("not arrow")))
+	require.IsType(t, ProcessingError{}, err)
+}
@@ -0,0 +1,62 @@
+package godata
+
+// bitmap marks which values of a series are valid, one bit per value with the least significant bit first.
+// A nil bitmap means every value is valid, which keeps series without nulls as cheap as plain slices.
+type bitmap []byte
+
+func (b bitmap) valid(i int) bool {
+	return b == nil || b[i/8]&(1<<uint(i%8)) != 0
+}
+
+// bitmapBuilder collects validity bits one value at a time and only allocates once a null shows up.
+type bitmapBuilder struct {
+	bits  bitmap
+	size  int
+	nulls int
+}
+
+func (bb *bitmapBuilder) append(valid bool) {
+	if !valid && bb.nulls == 0 {
+		bb.bits = make(bitmap, (bb.size+8)/8)
+		for i := 0; i < bb.size; i++ {
+			bb.bits[i/8] |= 1 << uint(i%8)
+		}
+	}
+	if !valid {
+		bb.nulls++
+	}
+
+	if bb.nulls > 0 {
+		if bb.size%8 == 0 && bb.size/8 == len(bb.bits) {
+			bb.bits = append(bb.bits, 0)
+		}
+		if valid {
+			bb.bits[bb.size/8] |= 1 << uint(bb.size%8)
+		}
+	}
+	bb.size++
+}
+
+func (bb *bitmapBuilder) appendFrom(b bitmap, size int) {
+	for i := 0; i < size; i++ {
+		bb.append(b.valid(i))
+	}
+}
+
+func (bb *bitmapBuilder) bitmap() bitmap {
+	if bb.nulls == 0 {
+		return nil
+	}
+	return bb.bits
+}
+
+// compareNulls orders a pair of values of which at least one is null, putting the nulls last.
+func compareNulls(xValid, yValid bool) int {
+	switch {
+	case xValid:
+		return -1
+	case yValid:
+		return 1
+	}
+	return 0
+}
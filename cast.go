@@ -0,0 +1,216 @@
+package godata
+
+import (
+	"github.com/pkg/errors"
+	"github.com/tkhandel/go-data/element"
+	"github.com/tkhandel/go-data/log"
+	"math"
+	"strconv"
+	"time"
+)
+
+// ParsePolicy decides what becomes of the values that cannot be parsed.
+type ParsePolicy[T any] struct {
+	onError parseOnError
+	value   T
+}
+
+type parseOnError int
+
+const (
+	failOnError parseOnError = iota
+	nullOnError
+	defaultOnError
+)
+
+// FailOnError makes parsing fail on the first value that cannot be parsed, with an error naming its row.
+func FailOnError[T any]() ParsePolicy[T] {
+	return ParsePolicy[T]{onError: failOnError}
+}
+
+// NullOnError turns the values that cannot be parsed into nulls.
+func NullOnError[T any]() ParsePolicy[T] {
+	return ParsePolicy[T]{onError: nullOnError}
+}
+
+// DefaultOnError replaces the values that cannot be parsed with value.
+func DefaultOnError[T any](value T) ParsePolicy[T] {
+	return ParsePolicy[T]{onError: defaultOnError, value: value}
+}
+
+func ParseInt(s StringSeries, policy ParsePolicy[int64]) (IntSeries, error) {
+	return parseValues(s, policy, element.IntType, func(val string) (int64, error) {
+		return strconv.ParseInt(val, 10, 64)
+	})
+}
+
+func ParseFloat(s StringSeries, policy ParsePolicy[float64]) (FloatSeries, error) {
+	return parseValues(s, policy, element.FloatType, func(val string) (float64, error) {
+		return strconv.ParseFloat(val, 64)
+	})
+}
+
+// ParseBool accepts the values accepted by strconv.ParseBool.
+func ParseBool(s StringSeries, policy ParsePolicy[bool]) (BoolSeries, error) {
+	return parseValues(s, policy, element.BoolType, strconv.ParseBool)
+}
+
+// ParseTime parses the values with layout, as understood by time.Parse.
+func ParseTime(s StringSeries, layout string, policy ParsePolicy[time.Time]) (TimeSeries, error) {
+	return parseValues(s, policy, element.TimeType, func(val string) (time.Time, error) {
+		return time.Parse(layout, val)
+	})
+}
+
+func parseValues[T any](s StringSeries, policy ParsePolicy[T], dType element.Dtype, parse func(string) (T, error)) (Series[T], error) {
+	out := Series[T]{}
+	if s.Size() > 0 {
+		out.data = make([]T, s.Size())
+	}
+	bits := bitmapBuilder{}
+	for x, entry := range s.data {
+		if !s.Valid(x) {
+			bits.append(false)
+			continue
+		}
+		val, err := parse(entry)
+		if err != nil {
+			switch policy.onError {
+			case failOnError:
+				parseErr := ProcessingError{Err: errors.Wrapf(err, "parsing row %d as %s", x, dType)}
+				log.Get().Error(parseErr.Error())
+				return Series[T]{}, parseErr
+			case nullOnError:
+				bits.append(false)
+				continue
+			}
+			val = policy.value
+		}
+		out.data[x] = val
+		bits.append(true)
+	}
+	out.valid = bits.bitmap()
+	return out, nil
+}
+
+// Rounding chooses how AsInt turns floats into ints.
+type Rounding int
+
+const (
+	RoundTowardZero Rounding = iota
+	RoundDown
+	RoundUp
+	// RoundHalfAway rounds to the nearest int, and halves away from zero.
+	RoundHalfAway
+	// RoundHalfEven rounds to the nearest int, and halves to the even one.
+	RoundHalfEven
+)
+
+func (r Rounding) round(val float64) float64 {
+	switch r {
+	case RoundDown:
+		return math.Floor(val)
+	case RoundUp:
+		return math.Ceil(val)
+	case RoundHalfAway:
+		return math.Round(val)
+	case RoundHalfEven:
+		return math.RoundToEven(val)
+	}
+	return math.Trunc(val)
+}
+
+// AsInt rounds the values to ints. NaN, infinities and values out of the range of an int64 give nulls.
+func AsInt(s FloatSeries, mode Rounding) IntSeries {
+	return mapValues(s, func(entry float64) (int64, bool) {
+		rounded := mode.round(entry)
+		if math.IsNaN(rounded) || rounded < math.MinInt64 || rounded >= math.MaxInt64 {
+			return 0, false
+		}
+		return int64(rounded), true
+	})
+}
+
+// Format turns the values into strings. Floats are written in their shortest form and times in RFC 3339.
+func Format[T Value](s Series[T]) StringSeries {
+	return mapValues(s, func(entry T) (string, bool) {
+		switch val := any(entry).(type) {
+		case int64:
+			return strconv.FormatInt(val, 10), true
+		case float64:
+			return strconv.FormatFloat(val, 'g', -1, 64), true
+		case string:
+			return val, true
+		case bool:
+			return strconv.FormatBool(val), true
+		case time.Time:
+			return val.Format(time.RFC3339Nano), true
+		}
+		return "", false
+	})
+}
+
+// FormatTime turns the times into strings with layout, as understood by time.Time.Format.
+func FormatTime(s TimeSeries, layout string) StringSeries {
+	return mapValues(s, func(entry time.Time) (string, bool) { return entry.Format(layout), true })
+}
+
+// Cast converts column colName to dType. Strings are parsed as by ParseInt, ParseFloat, ParseBool and ParseTime
+// with FailOnError, times in RFC 3339; any type becomes a string as by Format; ints become floats and floats
+// become ints rounded toward zero, as by AsInt. The column keeps its position.
+func (df DataFrame) Cast(colName string, dType element.Dtype) (DataFrame, error) {
+	col, ok := df.columns[colName]
+	if !ok {
+		err := Unknown{What: "column", Value: colName}
+		log.Get().Error(err.Error())
+		return DataFrame{}, err
+	}
+	if col.dType == dType {
+		return df.Clone(), nil
+	}
+
+	cast, err := castSeries(df.series[colName], dType)
+	if err != nil {
+		log.Get().Error(err.Error())
+		return DataFrame{}, err
+	}
+	return df.replaceSeries(colName, cast)
+}
+
+func castSeries(series AnySeries, dType element.Dtype) (AnySeries, error) {
+	if dType == element.StringType {
+		switch s := series.(type) {
+		case IntSeries:
+			return Format(s), nil
+		case FloatSeries:
+			return Format(s), nil
+		case BoolSeries:
+			return Format(s), nil
+		case TimeSeries:
+			return Format(s), nil
+		}
+	}
+
+	switch s := series.(type) {
+	case StringSeries:
+		switch dType {
+		case element.IntType:
+			return ParseInt(s, FailOnError[int64]())
+		case element.FloatType:
+			return ParseFloat(s, FailOnError[float64]())
+		case element.BoolType:
+			return ParseBool(s, FailOnError[bool]())
+		case element.TimeType:
+			return ParseTime(s, time.RFC3339Nano, FailOnError[time.Time]())
+		}
+	case IntSeries:
+		if dType == element.FloatType {
+			return AsFloat(s), nil
+		}
+	case FloatSeries:
+		if dType == element.IntType {
+			return AsInt(s, RoundTowardZero), nil
+		}
+	}
+	return nil, Unsupported{What: "cast", Value: series.Dtype().String() + " to " + dType.String()}
+}
@@ -0,0 +1,78 @@
+package godata
+
+import (
+	"github.com/stretchr/testify/require"
+	"github.com/tkhandel/go-data/element"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	s := NewStringSeries("1", "two", "3").AppendNull()
+
+	_, err := ParseInt(s, FailOnError[int64]())
+	require.IsType(t, ProcessingError{}, err)
+	require.Contains(t, err.Error(), "parsing row 1 as Integer")
+
+	ints, err := ParseInt(s, NullOnError[int64]())
+	require.NoError(t, err)
+	require.Equal(t, TruthFilter{false, true, false, true}, ints.IsNull())
+	require.Equal(t, int64(3), ints.Index(2))
+
+	ints, err = ParseInt(s, DefaultOnError(int64(-1)))
+	require.NoError(t, err)
+	require.Equal(t, NewIntSeries(1, -1, 3).AppendNull(), ints)
+
+	floats, err := ParseFloat(NewStringSeries("1.5", "x"), DefaultOnError(math.Inf(1)))
+	require.NoError(t, err)
+	require.Equal(t, NewFloatSeries(1.5, math.Inf(1)), floats)
+
+	bools, err := ParseBool(NewStringSeries("true", "F", "yes"), NullOnError[bool]())
+	require.NoError(t, err)
+	require.Equal(t, NewBoolSeries(true, false).AppendNull(), bools)
+
+	times, err := ParseTime(NewStringSeries("2020-01-02"), "2006-01-02", FailOnError[time.Time]())
+	require.NoError(t, err)
+	require.Equal(t, NewTimeSeries(time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)), times)
+	require.Equal(t, NewStringSeries("02/01/2020"), FormatTime(times, "02/01/2006"))
+}
+
+func TestAsIntAndFormat(t *testing.T) {
+	s := NewFloatSeries(2.5, -2.5, 1.2, math.NaN(), 1e19)
+	require.Equal(t, []int64{2, -2, 1}, AsInt(s, RoundTowardZero).data[:3])
+	require.Equal(t, []int64{2, -3, 1}, AsInt(s, RoundDown).data[:3])
+	require.Equal(t, []int64{3, -2, 2}, AsInt(s, RoundUp).data[:3])
+	require.Equal(t, []int64{3, -3, 1}, AsInt(s, RoundHalfAway).data[:3])
+	require.Equal(t, []int64{2, -2, 1}, AsInt(s, RoundHalfEven).data[:3])
+	require.Equal(t, TruthFilter{false, false, false, true, true}, AsInt(s, RoundHalfEven).IsNull())
+
+	require.Equal(t, NewStringSeries("2.5", "-2.5", "1.2", "NaN", "1e+19"), Format(s))
+	require.Equal(t, NewStringSeries("1", "2").AppendNull(), Format(NewIntSeries(1, 2).AppendNull()))
+	require.Equal(t, NewStringSeries("true"), Format(NewBoolSeries(true)))
+}
+
+func TestDataFrame_Cast(t *testing.T) {
+	df := testDF()
+	cast, err := df.Cast(col3, element.FloatType)
+	require.NoError(t, err)
+	floats, err := cast.FloatColumn(col3)
+	require.NoError(t, err)
+	require.Equal(t, NewFloatSeries(5, 6, 7), floats)
+
+	cast, err = cast.Cast(col3, element.StringType)
+	require.NoError(t, err)
+	cast, err = cast.Cast(col3, element.IntType)
+	require.NoError(t, err)
+	ints, err := cast.IntColumn(col3)
+	require.NoError(t, err)
+	require.Equal(t, col3Val, ints)
+	require.Equal(t, df.ColumnNames(), cast.ColumnNames())
+
+	_, err = df.Cast(col1, element.IntType)
+	require.IsType(t, ProcessingError{}, err)
+	_, err = df.Cast(col3, element.TimeType)
+	require.IsType(t, Unsupported{}, err)
+	_, err = df.Cast("foo", element.IntType)
+	require.IsType(t, Unknown{}, err)
+}
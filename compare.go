@@ -0,0 +1,114 @@
+package godata
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// Value is the constraint for the types of values a frame can hold.
+type Value interface {
+	int64 | float64 | string | bool | time.Time
+}
+
+// The comparisons below give false where a value is null. Floats are ordered as when sorting, with NaN below
+// every other value, false is below true and times are compared as instants, whatever their location.
+
+func EqScalar[T Value](s Series[T], value T) TruthFilter {
+	return compareScalar(s, value, func(c int) bool { return c == 0 })
+}
+
+func NeScalar[T Value](s Series[T], value T) TruthFilter {
+	return compareScalar(s, value, func(c int) bool { return c != 0 })
+}
+
+func LtScalar[T Value](s Series[T], value T) TruthFilter {
+	return compareScalar(s, value, func(c int) bool { return c < 0 })
+}
+
+func LeScalar[T Value](s Series[T], value T) TruthFilter {
+	return compareScalar(s, value, func(c int) bool { return c <= 0 })
+}
+
+func GtScalar[T Value](s Series[T], value T) TruthFilter {
+	return compareScalar(s, value, func(c int) bool { return c > 0 })
+}
+
+func GeScalar[T Value](s Series[T], value T) TruthFilter {
+	return compareScalar(s, value, func(c int) bool { return c >= 0 })
+}
+
+// Eq and the other comparisons between series compare the values at the same position of x and y, which must
+// have the same length.
+func Eq[T Value](x, y Series[T]) (TruthFilter, error) {
+	return compareSeries(x, y, func(c int) bool { return c == 0 })
+}
+
+func Ne[T Value](x, y Series[T]) (TruthFilter, error) {
+	return compareSeries(x, y, func(c int) bool { return c != 0 })
+}
+
+func Lt[T Value](x, y Series[T]) (TruthFilter, error) {
+	return compareSeries(x, y, func(c int) bool { return c < 0 })
+}
+
+func Le[T Value](x, y Series[T]) (TruthFilter, error) {
+	return compareSeries(x, y, func(c int) bool { return c <= 0 })
+}
+
+func Gt[T Value](x, y Series[T]) (TruthFilter, error) {
+	return compareSeries(x, y, func(c int) bool { return c > 0 })
+}
+
+func Ge[T Value](x, y Series[T]) (TruthFilter, error) {
+	return compareSeries(x, y, func(c int) bool { return c >= 0 })
+}
+
+// Between returns a filter that is true where the values are within lo and hi, both included.
+func Between[T Value](s Series[T], lo, hi T) TruthFilter {
+	compare := comparator[T]()
+	return s.Filter(func(entry T) bool { return compare(entry, lo) >= 0 && compare(entry, hi) <= 0 })
+}
+
+// IsIn returns a filter that is true where the values are equal to one of values.
+func IsIn[T Value](s Series[T], values ...T) TruthFilter {
+	compare := comparator[T]()
+	set := slices.Clone(values)
+	slices.SortFunc(set, compare)
+	return s.Filter(func(entry T) bool {
+		_, found := slices.BinarySearchFunc(set, entry, compare)
+		return found
+	})
+}
+
+func compareScalar[T Value](s Series[T], value T, accept func(c int) bool) TruthFilter {
+	compare := comparator[T]()
+	return s.Filter(func(entry T) bool { return accept(compare(entry, value)) })
+}
+
+func compareSeries[T Value](x, y Series[T], accept func(c int) bool) (TruthFilter, error) {
+	compare := comparator[T]()
+	out, err := combine(x, y, func(a, b T) (bool, bool) { return accept(compare(a, b)), true })
+	if err != nil {
+		return nil, err
+	}
+	return out.FillNA(false).data, nil
+}
+
+// comparator returns the function ordering values of type T ascending.
+func comparator[T Value]() func(a, b T) int {
+	var compare any
+	switch any(*new(T)).(type) {
+	case int64:
+		compare = cmp.Compare[int64]
+	case float64:
+		compare = cmp.Compare[float64]
+	case string:
+		compare = cmp.Compare[string]
+	case bool:
+		compare = compareBools
+	case time.Time:
+		compare = time.Time.Compare
+	}
+	return compare.(func(a, b T) int)
+}
@@ -0,0 +1,62 @@
+package godata
+
+import (
+	"github.com/stretchr/testify/require"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCompareScalar(t *testing.T) {
+	ints := NewIntSeries(1, 2, 3).AppendNull()
+	require.Equal(t, TruthFilter{false, true, false, false}, EqScalar(ints, 2))
+	require.Equal(t, TruthFilter{true, false, true, false}, NeScalar(ints, 2))
+	require.Equal(t, TruthFilter{true, false, false, false}, LtScalar(ints, 2))
+	require.Equal(t, TruthFilter{true, true, false, false}, LeScalar(ints, 2))
+	require.Equal(t, TruthFilter{false, false, true, false}, GtScalar(ints, 2))
+	require.Equal(t, TruthFilter{false, true, true, false}, GeScalar(ints, 2))
+	require.Equal(t, TruthFilter{false, true, true, false}, Between(ints, 2, 3))
+	require.Equal(t, TruthFilter{true, false, true, false}, IsIn(ints, 3, 1, 7))
+
+	floats := NewFloatSeries(math.NaN(), -1, 2.5)
+	require.Equal(t, TruthFilter{true, true, false}, LtScalar(floats, 0))
+
+	strs := NewStringSeries("apple", "pear", "plum")
+	require.Equal(t, TruthFilter{false, true, true}, GtScalar(strs, "orange"))
+	require.Equal(t, TruthFilter{true, false, true}, IsIn(strs, "plum", "apple"))
+
+	require.Equal(t, TruthFilter{false, true}, GtScalar(NewBoolSeries(false, true), false))
+
+	noon := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	times := NewTimeSeries(noon.In(time.FixedZone("CET", 3600)), noon.Add(time.Hour))
+	require.Equal(t, TruthFilter{true, false}, EqScalar(times, noon))
+	require.Equal(t, TruthFilter{true, true}, Between(times, noon, noon.Add(time.Hour)))
+	require.Equal(t, TruthFilter{true, false}, IsIn(times, noon))
+}
+
+func TestCompareSeries(t *testing.T) {
+	x := NewFloatSeries(1, 2, 3).AppendNull()
+	y := NewFloatSeries(3, 2, 1, 0)
+
+	eq, err := Eq(x, y)
+	require.NoError(t, err)
+	require.Equal(t, TruthFilter{false, true, false, false}, eq)
+	ne, err := Ne(x, y)
+	require.NoError(t, err)
+	require.Equal(t, TruthFilter{true, false, true, false}, ne)
+	lt, err := Lt(x, y)
+	require.NoError(t, err)
+	require.Equal(t, TruthFilter{true, false, false, false}, lt)
+	le, err := Le(x, y)
+	require.NoError(t, err)
+	require.Equal(t, TruthFilter{true, true, false, false}, le)
+	gt, err := Gt(x, y)
+	require.NoError(t, err)
+	require.Equal(t, TruthFilter{false, false, true, false}, gt)
+	ge, err := Ge(x, y)
+	require.NoError(t, err)
+	require.Equal(t, TruthFilter{false, true, true, false}, ge)
+
+	_, err = Eq(x, NewFloatSeries(1))
+	require.IsType(t, LengthMismatch{}, err)
+}
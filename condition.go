@@ -0,0 +1,116 @@
+package godata
+
+import "time"
+
+// Condition selects the rows of a frame that should be kept.
+type Condition func(df DataFrame) (TruthFilter, error)
+
+func (c Condition) And(other Condition) Condition {
+	return c.combine(other, TruthFilter.And)
+}
+
+func (c Condition) Or(other Condition) Condition {
+	return c.combine(other, TruthFilter.Or)
+}
+
+func (c Condition) Xor(other Condition) Condition {
+	return c.combine(other, TruthFilter.Xor)
+}
+
+func (c Condition) combine(other Condition, op func(TruthFilter, TruthFilter) (TruthFilter, error)) Condition {
+	return func(df DataFrame) (TruthFilter, error) {
+		filter, err := c(df)
+		if err != nil {
+			return nil, err
+		}
+		otherFilter, err := other(df)
+		if err != nil {
+			return nil, err
+		}
+		return op(filter, otherFilter)
+	}
+}
+
+func (c Condition) Not() Condition {
+	return func(df DataFrame) (TruthFilter, error) {
+		filter, err := c(df)
+		if err != nil {
+			return nil, err
+		}
+		return filter.Not(), nil
+	}
+}
+
+// columnCondition tests the values of column name, which must hold values of type T.
+func columnCondition[T any](name string, test func(Series[T]) TruthFilter) Condition {
+	return func(df DataFrame) (TruthFilter, error) {
+		series, err := ColumnOf[T](df, name)
+		if err != nil {
+			return nil, err
+		}
+		return test(series), nil
+	}
+}
+
+// StringCol names a string column to build conditions on, e.g. StringCol("region").NotEqual("EU").
+type StringCol string
+
+func (s StringCol) Equal(str string) Condition {
+	return columnCondition(string(s), func(series StringSeries) TruthFilter { return Equal(series, str) })
+}
+
+func (s StringCol) NotEqual(str string) Condition {
+	return columnCondition(string(s), func(series StringSeries) TruthFilter { return NotEqual(series, str) })
+}
+
+func (s StringCol) Filter(accept func(string) bool) Condition {
+	return columnCondition(string(s), func(series StringSeries) TruthFilter { return series.Filter(accept) })
+}
+
+// IntCol names an int column to build conditions on, e.g. IntCol("qty").GreaterThan(0).
+type IntCol string
+
+func (i IntCol) GreaterThan(value int64) Condition {
+	return columnCondition(string(i), func(series IntSeries) TruthFilter { return GreaterThan(series, value) })
+}
+
+func (i IntCol) SmallerThan(value int64) Condition {
+	return columnCondition(string(i), func(series IntSeries) TruthFilter { return SmallerThan(series, value) })
+}
+
+func (i IntCol) Filter(accept func(int64) bool) Condition {
+	return columnCondition(string(i), func(series IntSeries) TruthFilter { return series.Filter(accept) })
+}
+
+// FloatCol names a float column to build conditions on, e.g. FloatCol("price").GreaterThan(10).
+type FloatCol string
+
+func (f FloatCol) GreaterThan(value float64) Condition {
+	return columnCondition(string(f), func(series FloatSeries) TruthFilter { return GreaterThan(series, value) })
+}
+
+func (f FloatCol) SmallerThan(value float64) Condition {
+	return columnCondition(string(f), func(series FloatSeries) TruthFilter { return SmallerThan(series, value) })
+}
+
+func (f FloatCol) Filter(accept func(float64) bool) Condition {
+	return columnCondition(string(f), func(series FloatSeries) TruthFilter { return series.Filter(accept) })
+}
+
+// BoolCol names a bool column to build conditions on, e.g. BoolCol("active").IsTrue().
+type BoolCol string
+
+func (b BoolCol) IsTrue() Condition {
+	return columnCondition(string(b), IsTrue)
+}
+
+// TimeCol names a time column to build conditions on, e.g. TimeCol("created").After(cutoff).
+type TimeCol string
+
+func (t TimeCol) Before(value time.Time) Condition {
+	return columnCondition(string(t), func(series TimeSeries) TruthFilter { return Before(series, value) })
+}
+
+func (t TimeCol) After(value time.Time) Condition {
+	return columnCondition(string(t), func(series TimeSeries) TruthFilter { return After(series, value) })
+}
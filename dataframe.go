@@ -1,15 +1,24 @@
 package godata
 
 import (
+	"fmt"
 	"github.com/tkhandel/go-data/element"
 	"github.com/tkhandel/go-data/log"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 )
 
+// DataFrame holds named columns of equal length. The columns keep the order in which they were added, which is
+// the order every writer and printer uses.
 type DataFrame struct {
-	columns       map[string]Column
-	stringColumns map[string]StringSeries
-	intColumns    map[string]IntSeries
-	floatColumns  map[string]FloatSeries
+	columns map[string]Column
+	series  map[string]AnySeries
+	names   []string
+	// sized is set once a column has been set, from when every column must have the same number of rows.
+	// Until then, the columns hold no rows.
+	sized bool
 }
 
 type Column struct {
@@ -38,12 +47,24 @@ func NewFloatColumn(name string) Column {
 	}
 }
 
+func NewBoolColumn(name string) Column {
+	return Column{
+		name:  name,
+		dType: element.BoolType,
+	}
+}
+
+func NewTimeColumn(name string) Column {
+	return Column{
+		name:  name,
+		dType: element.TimeType,
+	}
+}
+
 func NewDataFrame(columns ...Column) (DataFrame, error) {
 	df := DataFrame{
-		columns:       make(map[string]Column),
-		stringColumns: make(map[string]StringSeries),
-		intColumns:    make(map[string]IntSeries),
-		floatColumns:  make(map[string]FloatSeries),
+		columns: make(map[string]Column),
+		series:  make(map[string]AnySeries),
 	}
 
 	for _, col := range columns {
@@ -53,124 +74,487 @@ func NewDataFrame(columns ...Column) (DataFrame, error) {
 			return DataFrame{}, err
 		}
 		df.columns[col.name] = col
+		df.names = append(df.names, col.name)
 
-		switch col.dType {
-		case element.StringType:
-			df.stringColumns[col.name] = NewStringSeries()
-		case element.IntType:
-			df.intColumns[col.name] = NewIntSeries()
-		case element.FloatType:
-			df.floatColumns[col.name] = NewFloatSeries()
-		default:
+		series := newSeries(col.dType)
+		if series == nil {
 			err := Unknown{What: "column type", Value: col.dType.String()}
 			log.Get().Error(err.Error())
 			return DataFrame{}, err
 		}
+		df.series[col.name] = series
 	}
 	return df, nil
 }
 
+// Columns returns the columns in the order of the frame.
 func (df DataFrame) Columns() (columns []Column) {
-	for _, col := range df.columns {
-		columns = append(columns, col)
+	for _, name := range df.names {
+		columns = append(columns, df.columns[name])
 	}
 	return columns
 }
 
-func (df DataFrame) StringColumn(colName string) (StringSeries, error) {
-	col, ok := df.stringColumns[colName]
+// ColumnNames returns the names of the columns in the order of the frame.
+func (df DataFrame) ColumnNames() []string {
+	return append([]string(nil), df.names...)
+}
+
+// Column returns the series of column colName, whatever the type of its values.
+func (df DataFrame) Column(colName string) (AnySeries, error) {
+	series, ok := df.series[colName]
 	if !ok {
 		err := Unknown{What: "column", Value: colName}
 		log.Get().Errorf(err.Error())
-		return StringSeries{}, err
+		return nil, err
 	}
-	return col.Clone(), nil
+	return series, nil
 }
 
-func (df DataFrame) FloatColumn(colName string) (FloatSeries, error) {
-	col, ok := df.floatColumns[colName]
+// ColumnOf returns a copy of column colName, which must hold values of type T.
+func ColumnOf[T any](df DataFrame, colName string) (Series[T], error) {
+	series, ok := df.series[colName].(Series[T])
 	if !ok {
 		err := Unknown{What: "column", Value: colName}
 		log.Get().Errorf(err.Error())
-		return FloatSeries{}, err
+		return Series[T]{}, err
 	}
-	return col.Clone(), nil
+	return series.Clone(), nil
+}
+
+func (df DataFrame) StringColumn(colName string) (StringSeries, error) {
+	return ColumnOf[string](df, colName)
+}
+
+func (df DataFrame) FloatColumn(colName string) (FloatSeries, error) {
+	return ColumnOf[float64](df, colName)
 }
 
 func (df DataFrame) IntColumn(colName string) (IntSeries, error) {
-	col, ok := df.intColumns[colName]
-	if !ok {
-		err := Unknown{What: "column", Value: colName}
-		log.Get().Errorf(err.Error())
-		return IntSeries{}, err
-	}
-	return col.Clone(), nil
+	return ColumnOf[int64](df, colName)
+}
+
+func (df DataFrame) BoolColumn(colName string) (BoolSeries, error) {
+	return ColumnOf[bool](df, colName)
+}
+
+func (df DataFrame) TimeColumn(colName string) (TimeSeries, error) {
+	return ColumnOf[time.Time](df, colName)
 }
 
 func (df DataFrame) DropColumn(name string) DataFrame {
 	changed := df.Clone()
-
 	delete(changed.columns, name)
+	delete(changed.series, name)
+	if pos := slices.Index(changed.names, name); pos >= 0 {
+		changed.names = slices.Delete(changed.names, pos, pos+1)
+	}
+	return changed
+}
 
-	// The column name cannot be duplicated, so the delete will actually work only on one of them
-	delete(changed.stringColumns, name)
-	delete(changed.intColumns, name)
-	delete(changed.floatColumns, name)
+// Select returns the named columns in the given order.
+func (df DataFrame) Select(names ...string) (DataFrame, error) {
+	var columns []Column
+	for _, name := range names {
+		col, ok := df.columns[name]
+		if !ok {
+			err := Unknown{What: "column", Value: name}
+			log.Get().Error(err.Error())
+			return DataFrame{}, err
+		}
+		columns = append(columns, col)
+	}
 
-	return changed
+	selected, err := NewDataFrame(columns...)
+	if err != nil {
+		return DataFrame{}, err
+	}
+	for _, name := range names {
+		selected.series[name] = df.series[name]
+	}
+	selected.sized = df.sized
+	return selected, nil
 }
 
-func (df DataFrame) Clone() DataFrame {
-	cloned, _ := NewDataFrame(df.Columns()...)
+// Reorder moves the named columns to the front in the given order. The other columns follow in their current
+// order.
+func (df DataFrame) Reorder(names ...string) (DataFrame, error) {
+	front := make(map[string]bool, len(names))
+	for _, name := range names {
+		front[name] = true
+	}
+	for _, name := range df.names {
+		if !front[name] {
+			names = append(names, name)
+		}
+	}
+	return df.Select(names...)
+}
 
-	for _, col := range df.columns {
-		switch col.dType {
-		case element.StringType:
-			cloned.stringColumns[col.name] = df.stringColumns[col.name]
-		case element.IntType:
-			cloned.intColumns[col.name] = df.intColumns[col.name]
-		case element.FloatType:
-			cloned.floatColumns[col.name] = df.floatColumns[col.name]
+// Rename gives the columns keyed by their current name the matching new name, keeping their position.
+func (df DataFrame) Rename(names map[string]string) (DataFrame, error) {
+	renamed := df.Clone()
+	renamed.columns = make(map[string]Column, len(df.columns))
+	renamed.series = make(map[string]AnySeries, len(df.series))
+	for old := range names {
+		if _, ok := df.columns[old]; !ok {
+			err := Unknown{What: "column", Value: old}
+			log.Get().Error(err.Error())
+			return DataFrame{}, err
 		}
 	}
-	return cloned
+
+	for pos, old := range df.names {
+		name, ok := names[old]
+		if !ok {
+			name = old
+		}
+		if _, ok := renamed.columns[name]; ok {
+			err := Duplicate{What: "column", Value: name}
+			log.Get().Error(err.Error())
+			return DataFrame{}, err
+		}
+		renamed.columns[name] = Column{name: name, dType: df.columns[old].dType}
+		renamed.series[name] = df.series[old]
+		renamed.names[pos] = name
+	}
+	return renamed, nil
 }
 
-func (df DataFrame) SetStringColumn(colName string, value StringSeries) (DataFrame, error) {
-	changed := df.Clone()
-	if _, ok := changed.columns[colName]; !ok {
-		changed.columns[colName] = NewStringColumn(colName)
-	} else if _, ok := changed.stringColumns[colName]; !ok {
-		err := Duplicate{What: "non-string column", Value: colName}
+// InsertColumnAt stores a copy of value as a new column colName at position pos, where 0 puts it first and
+// the number of columns puts it last.
+func (df DataFrame) InsertColumnAt(pos int, colName string, value AnySeries) (DataFrame, error) {
+	if value == nil {
+		err := Unsupported{What: "series type", Value: "nil"}
 		log.Get().Warn(err.Error())
-		return changed, err
+		return df, err
+	}
+	if value.Dtype() == 0 {
+		err := Unsupported{What: "series type", Value: fmt.Sprintf("%T", value)}
+		log.Get().Warn(err.Error())
+		return df, err
+	}
+	if pos < 0 || pos > len(df.names) {
+		err := Unknown{What: "column position", Value: strconv.Itoa(pos)}
+		log.Get().Error(err.Error())
+		return df, err
 	}
-	changed.stringColumns[colName] = value.Clone()
+	if _, ok := df.columns[colName]; ok {
+		err := Duplicate{What: "column", Value: colName}
+		log.Get().Error(err.Error())
+		return df, err
+	}
+
+	changed, err := df.setSeries(colName, newSeries(value.Dtype()).concatAny(value))
+	if err != nil {
+		return df, err
+	}
+	changed.names = slices.Insert(changed.names[:len(changed.names)-1], pos, colName)
 	return changed, nil
 }
 
-func (df DataFrame) SetIntColumn(colName string, value IntSeries) (DataFrame, error) {
-	changed := df.Clone()
-	if _, ok := changed.columns[colName]; !ok {
-		changed.columns[colName] = NewIntColumn(colName)
-	} else if _, ok := changed.intColumns[colName]; !ok {
-		err := Duplicate{What: "non-int column", Value: colName}
+func (df DataFrame) Clone() DataFrame {
+	cloned, _ := NewDataFrame(df.Columns()...)
+	for name, series := range df.series {
+		cloned.series[name] = series
+	}
+	cloned.sized = df.sized
+	return cloned
+}
+
+// SetColumn stores a copy of value as column colName. An existing column of that name is replaced when it
+// holds values of the same type.
+func SetColumn[T any](df DataFrame, colName string, value Series[T]) (DataFrame, error) {
+	if value.Dtype() == 0 {
+		err := Unsupported{What: "series type", Value: fmt.Sprintf("%T", value)}
 		log.Get().Warn(err.Error())
-		return changed, err
+		return df, err
 	}
-	changed.intColumns[colName] = value.Clone()
-	return changed, nil
+	return df.setSeries(colName, value.Clone())
+}
+
+func (df DataFrame) SetStringColumn(colName string, value StringSeries) (DataFrame, error) {
+	return SetColumn(df, colName, value)
+}
+
+func (df DataFrame) SetIntColumn(colName string, value IntSeries) (DataFrame, error) {
+	return SetColumn(df, colName, value)
 }
 
 func (df DataFrame) SetFloatColumn(colName string, value FloatSeries) (DataFrame, error) {
+	return SetColumn(df, colName, value)
+}
+
+func (df DataFrame) SetBoolColumn(colName string, value BoolSeries) (DataFrame, error) {
+	return SetColumn(df, colName, value)
+}
+
+func (df DataFrame) SetTimeColumn(colName string, value TimeSeries) (DataFrame, error) {
+	return SetColumn(df, colName, value)
+}
+
+// setSeries stores value as column colName without copying it.
+func (df DataFrame) setSeries(colName string, value AnySeries) (DataFrame, error) {
+	if value == nil {
+		err := Unsupported{What: "series type", Value: "nil"}
+		log.Get().Warn(err.Error())
+		return df, err
+	}
 	changed := df.Clone()
-	if _, ok := changed.columns[colName]; !ok {
-		changed.columns[colName] = NewFloatColumn(colName)
-	} else if _, ok := changed.floatColumns[colName]; !ok {
-		err := Duplicate{What: "non-float column", Value: colName}
+	if col, ok := changed.columns[colName]; !ok {
+		changed.columns[colName] = Column{name: colName, dType: value.Dtype()}
+		changed.names = append(changed.names, colName)
+	} else if col.dType != value.Dtype() {
+		err := Duplicate{What: "non-" + strings.ToLower(value.Dtype().String()) + " column", Value: colName}
 		log.Get().Warn(err.Error())
 		return changed, err
 	}
-	changed.floatColumns[colName] = value.Clone()
+	if err := changed.checkLength(colName, value.Size()); err != nil {
+		log.Get().Warn(err.Error())
+		return df, err
+	}
+	changed.series[colName] = value
+	changed.fillEmpty(value.Size())
+	changed.sized = true
 	return changed, nil
 }
+
+// fillEmpty gives the columns of a frame that was never sized the given number of nulls, so that every column of
+// the frame has as many rows.
+func (df DataFrame) fillEmpty(rows int) {
+	if df.sized || rows == 0 {
+		return
+	}
+	nulls := make([]int, rows)
+	for i := range nulls {
+		nulls[i] = -1
+	}
+	for name, series := range df.series {
+		if series.Size() == 0 {
+			df.series[name] = series.takeAny(nulls)
+		}
+	}
+}
+
+// replaceSeries stores value in place of column colName, whatever the type of the values it held, keeping the
+// position of the column.
+func (df DataFrame) replaceSeries(colName string, value AnySeries) (DataFrame, error) {
+	if err := df.checkLength(colName, value.Size()); err != nil {
+		log.Get().Error(err.Error())
+		return DataFrame{}, err
+	}
+	changed := df.Clone()
+	changed.columns[colName] = Column{name: colName, dType: value.Dtype()}
+	changed.series[colName] = value
+	changed.fillEmpty(value.Size())
+	changed.sized = true
+	return changed, nil
+}
+
+// NRows returns the number of rows in the frame. The first column set on a frame decides it, and the columns
+// that were not set yet hold as many nulls.
+func (df DataFrame) NRows() int {
+	rows := 0
+	for name := range df.columns {
+		if size := df.columnSize(name); size > rows {
+			rows = size
+		}
+	}
+	return rows
+}
+
+// Row returns the values at position i keyed by column name.
+func (df DataFrame) Row(i int) (map[string]element.Element, error) {
+	if i < 0 || i >= df.NRows() {
+		err := Unknown{What: "row", Value: strconv.Itoa(i)}
+		log.Get().Error(err.Error())
+		return nil, err
+	}
+
+	row := make(map[string]element.Element, len(df.columns))
+	for name := range df.columns {
+		row[name] = df.element(name, i)
+	}
+	return row, nil
+}
+
+// Rows returns an iterator over the rows of the frame, each holding the values of the given columns in the
+// given order, or of every column in the order of the frame when no column is given.
+func (df DataFrame) Rows(columns ...string) *RowIterator {
+	if len(columns) == 0 {
+		columns = df.ColumnNames()
+	}
+
+	rows := &RowIterator{df: df, columns: columns, pos: -1}
+	for _, name := range columns {
+		if _, ok := df.columns[name]; !ok {
+			rows.err = Unknown{What: "column", Value: name}
+			log.Get().Error(rows.err.Error())
+			break
+		}
+	}
+	return rows
+}
+
+type RowIterator struct {
+	df      DataFrame
+	columns []string
+	pos     int
+	err     error
+}
+
+func (r *RowIterator) HasNext() bool {
+	return r.err == nil && r.pos+1 < r.df.NRows()
+}
+
+// Next returns an iterator over the values of the next row.
+func (r *RowIterator) Next() *element.Iterator {
+	r.pos++
+	values := make([]interface{}, 0, len(r.columns))
+	for _, name := range r.columns {
+		values = append(values, r.df.value(name, r.pos))
+	}
+	return element.NewIterator(values)
+}
+
+func (r *RowIterator) Error() error {
+	return r.err
+}
+
+func (df DataFrame) columnSize(name string) int {
+	return df.series[name].Size()
+}
+
+// checkLength verifies that a series of the given size can be stored as column name without leaving the frame
+// with columns of different lengths. Any size fits a frame that was never sized.
+func (df DataFrame) checkLength(name string, size int) error {
+	if !df.sized {
+		return nil
+	}
+	for other := range df.columns {
+		if other == name {
+			continue
+		}
+		if otherSize := df.columnSize(other); otherSize != size {
+			return LengthMismatch{What: "column " + name, Expected: otherSize, Actual: size}
+		}
+	}
+	return nil
+}
+
+// value returns the value of column name at row i, or nil when it is null.
+func (df DataFrame) value(name string, i int) interface{} {
+	return df.series[name].value(i)
+}
+
+func (df DataFrame) valid(name string, i int) bool {
+	return df.series[name].Valid(i)
+}
+
+func (df DataFrame) element(name string, i int) element.Element {
+	return df.series[name].Element(i)
+}
+
+// Filter keeps the rows of every column for which the filter is true.
+func (df DataFrame) Filter(filter TruthFilter) (DataFrame, error) {
+	if len(filter) != df.NRows() {
+		err := LengthMismatch{What: "filter", Expected: df.NRows(), Actual: len(filter)}
+		log.Get().Error(err.Error())
+		return DataFrame{}, err
+	}
+
+	var positions []int
+	for i, pass := range filter {
+		if pass {
+			positions = append(positions, i)
+		}
+	}
+	return df.take(positions), nil
+}
+
+// Where keeps the rows that satisfy the condition, e.g.
+//
+//	df.Where(FloatCol("price").GreaterThan(10).And(StringCol("region").NotEqual("EU")))
+func (df DataFrame) Where(cond Condition) (DataFrame, error) {
+	filter, err := cond(df)
+	if err != nil {
+		return DataFrame{}, err
+	}
+	return df.Filter(filter)
+}
+
+func (df DataFrame) takeValues(name string, rows []int) []interface{} {
+	values := make([]interface{}, len(rows))
+	for k, i := range rows {
+		values[k] = df.value(name, i)
+	}
+	return values
+}
+
+// setValues stores the values, which must all be nil or of the Go type backing dType, as column name.
+// Nil values are stored as nulls.
+func (df DataFrame) setValues(name string, dType element.Dtype, values []interface{}) (DataFrame, error) {
+	series := newSeries(dType)
+	if series == nil {
+		err := Unknown{What: "column type", Value: dType.String()}
+		log.Get().Error(err.Error())
+		return df, err
+	}
+	return df.setSeries(name, series.withValues(values))
+}
+
+// FillNA replaces the nulls of the named columns with the given values, which must be of the Go type backing
+// the column, e.g. int64 for an int column.
+func (df DataFrame) FillNA(values map[string]interface{}) (DataFrame, error) {
+	filled := df.Clone()
+	for name, value := range values {
+		col, ok := df.columns[name]
+		if !ok {
+			err := Unknown{What: "column", Value: name}
+			log.Get().Error(err.Error())
+			return DataFrame{}, err
+		}
+
+		series, ok := df.series[name].fillAny(value)
+		if !ok {
+			err := Unsupported{What: "fill value for " + col.dType.String() + " column " + name, Value: fmt.Sprintf("%T", value)}
+			log.Get().Error(err.Error())
+			return DataFrame{}, err
+		}
+		filled.series[name] = series
+	}
+	return filled, nil
+}
+
+// DropNA removes the rows holding a null in any of the given columns, or in any column when none is given.
+func (df DataFrame) DropNA(columns ...string) (DataFrame, error) {
+	if len(columns) == 0 {
+		columns = df.ColumnNames()
+	}
+	for _, name := range columns {
+		if _, ok := df.columns[name]; !ok {
+			err := Unknown{What: "column", Value: name}
+			log.Get().Error(err.Error())
+			return DataFrame{}, err
+		}
+	}
+
+	keep := make(TruthFilter, df.NRows())
+	for i := range keep {
+		keep[i] = true
+		for _, name := range columns {
+			keep[i] = keep[i] && df.valid(name, i)
+		}
+	}
+	return df.Filter(keep)
+}
+
+// take returns the rows at the given positions, in that order. Negative positions give rows of nulls.
+func (df DataFrame) take(positions []int) DataFrame {
+	taken := df.Clone()
+	for name, series := range df.series {
+		taken.series[name] = series.takeAny(positions)
+	}
+	taken.sized = true
+	return taken
+}
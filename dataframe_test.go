@@ -50,7 +50,80 @@ func TestDataFrame_Columns(t *testing.T) {
 		NewStringColumn(col2),
 		NewIntColumn(col3),
 		NewFloatColumn(col4)}
-	require.ElementsMatch(t, exp, df.Columns())
+	require.Equal(t, exp, df.Columns())
+	require.Equal(t, []string{col1, col2, col3, col4}, df.ColumnNames())
+
+	changed, err := df.DropColumn(col2).SetIntColumn("col5", col3Val)
+	require.NoError(t, err)
+	require.Equal(t, []string{col1, col3, col4, "col5"}, changed.ColumnNames())
+}
+
+func TestDataFrame_Select(t *testing.T) {
+	df := testDF()
+	selected, err := df.Select(col4, col1)
+	require.NoError(t, err)
+	require.Equal(t, []Column{NewFloatColumn(col4), NewStringColumn(col1)}, selected.Columns())
+	val, err := selected.FloatColumn(col4)
+	require.NoError(t, err)
+	require.Equal(t, col4Val, val)
+
+	_, err = df.Select(col1, "foo")
+	require.IsType(t, Unknown{}, err)
+	_, err = df.Select(col1, col1)
+	require.IsType(t, Duplicate{}, err)
+
+	reordered, err := df.Reorder(col3, col1)
+	require.NoError(t, err)
+	require.Equal(t, []string{col3, col1, col2, col4}, reordered.ColumnNames())
+	_, err = df.Reorder("foo")
+	require.IsType(t, Unknown{}, err)
+}
+
+func TestDataFrame_Rename(t *testing.T) {
+	df := testDF()
+	renamed, err := df.Rename(map[string]string{col1: col2, col2: col1, col3: "qty"})
+	require.NoError(t, err)
+	require.Equal(t, []Column{NewStringColumn(col2), NewStringColumn(col1), NewIntColumn("qty"), NewFloatColumn(col4)},
+		renamed.Columns())
+	val, err := renamed.StringColumn(col2)
+	require.NoError(t, err)
+	require.Equal(t, col1Val, val)
+	require.Equal(t, []string{col1, col2, col3, col4}, df.ColumnNames())
+
+	_, err = df.Rename(map[string]string{col1: col2})
+	require.IsType(t, Duplicate{}, err)
+	_, err = df.Rename(map[string]string{"foo": "bar"})
+	require.IsType(t, Unknown{}, err)
+}
+
+func TestDataFrame_InsertColumnAt(t *testing.T) {
+	df := testDF()
+	changed, err := df.InsertColumnAt(1, "col5", NewBoolSeries(true, false, true))
+	require.NoError(t, err)
+	require.Equal(t, []string{col1, "col5", col2, col3, col4}, changed.ColumnNames())
+	flags, err := changed.BoolColumn("col5")
+	require.NoError(t, err)
+	require.Equal(t, NewBoolSeries(true, false, true), flags)
+
+	changed, err = df.InsertColumnAt(4, "col5", col3Val)
+	require.NoError(t, err)
+	require.Equal(t, []string{col1, col2, col3, col4, "col5"}, changed.ColumnNames())
+
+	_, err = df.InsertColumnAt(5, "col5", col3Val)
+	require.IsType(t, Unknown{}, err)
+	_, err = df.InsertColumnAt(0, col1, col1Val)
+	require.IsType(t, Duplicate{}, err)
+	_, err = df.InsertColumnAt(0, "col5", NewIntSeries(1))
+	require.IsType(t, LengthMismatch{}, err)
+	changed, err = df.InsertColumnAt(0, "col5", NewSeries[int32](1, 2, 3))
+	require.IsType(t, Unsupported{}, err)
+	require.Equal(t, df, changed)
+	changed, err = df.InsertColumnAt(0, "col5", nil)
+	require.IsType(t, Unsupported{}, err)
+	require.Equal(t, df, changed)
+	changed, err = df.setSeries("col5", nil)
+	require.IsType(t, Unsupported{}, err)
+	require.Equal(t, df, changed)
 }
 
 func TestDataFrame_StringColumn(t *testing.T) {
@@ -148,7 +221,7 @@ func TestDataFrame_SetIntColumn_ReplaceColumnValue(t *testing.T) {
 
 func TestDataFrame_SetStringColumn_ReplaceColumnValue(t *testing.T) {
 	df := testDF()
-	newVal := NewStringSeries("five", "six")
+	newVal := NewStringSeries("seven", "eight", "nine")
 	changed, err := df.SetStringColumn(col2, newVal)
 	require.NoError(t, err)
 
@@ -183,11 +256,200 @@ func TestDataFrame_SetFloatColumn_DuplicateColumn(t *testing.T) {
 	require.Error(t, err)
 	require.IsType(t, Duplicate{}, err)
 
-	val, err := df.FloatColumn(col3)
+	val, err := df.IntColumn(col3)
 	require.NoError(t, err)
 	require.Equal(t, col3Val, val)
 }
 
+func TestDataFrame_SetIntColumn_LengthMismatch(t *testing.T) {
+	df := testDF()
+	changed, err := df.SetIntColumn("col5", NewIntSeries(1, 2))
+	require.IsType(t, LengthMismatch{}, err)
+	require.Equal(t, 3, err.(LengthMismatch).Expected)
+	require.Equal(t, 2, err.(LengthMismatch).Actual)
+	require.ElementsMatch(t, df.Columns(), changed.Columns())
+}
+
+func TestDataFrame_UnsetColumn(t *testing.T) {
+	df, err := NewDataFrame(NewStringColumn("a"), NewIntColumn("b"))
+	require.NoError(t, err)
+	df, err = df.SetStringColumn("a", NewStringSeries("x", "y"))
+	require.NoError(t, err)
+	require.Equal(t, 2, df.NRows())
+
+	row, err := df.Row(0)
+	require.NoError(t, err)
+	require.Equal(t, "x", row["a"].MustString())
+	b, err := df.IntColumn("b")
+	require.NoError(t, err)
+	require.Equal(t, NewIntSeries().AppendNull().AppendNull(), b)
+
+	filtered, err := df.Filter(TruthFilter{false, true})
+	require.NoError(t, err)
+	require.Equal(t, 1, filtered.NRows())
+	sorted, err := df.SortBy(SortKey{Column: "a", Descending: true})
+	require.NoError(t, err)
+	a, err := sorted.StringColumn("a")
+	require.NoError(t, err)
+	require.Equal(t, NewStringSeries("y", "x"), a)
+
+	df, err = df.SetIntColumn("b", NewIntSeries(1, 2))
+	require.NoError(t, err)
+	b, err = df.IntColumn("b")
+	require.NoError(t, err)
+	require.Equal(t, NewIntSeries(1, 2), b)
+}
+
+func TestDataFrame_EmptyAfterFilter(t *testing.T) {
+	df := testDF()
+	empty, err := df.Filter(TruthFilter{false, false, false})
+	require.NoError(t, err)
+	require.Equal(t, 0, empty.NRows())
+
+	changed, err := empty.SetIntColumn("col5", NewIntSeries(1, 2, 3, 4, 5))
+	require.IsType(t, LengthMismatch{}, err)
+	require.Equal(t, 0, err.(LengthMismatch).Expected)
+	require.Equal(t, empty, changed)
+
+	changed, err = empty.SetIntColumn("col5", NewIntSeries())
+	require.NoError(t, err)
+	require.Equal(t, 0, changed.NRows())
+	_, err = empty.DropColumn(col1).SetStringColumn(col2, NewStringSeries("x"))
+	require.IsType(t, LengthMismatch{}, err)
+}
+
+func TestDataFrame_NRows(t *testing.T) {
+	require.Equal(t, 3, testDF().NRows())
+
+	df, err := NewDataFrame(NewStringColumn(col1))
+	require.NoError(t, err)
+	require.Equal(t, 0, df.NRows())
+}
+
+func TestDataFrame_Row(t *testing.T) {
+	df := testDF()
+	row, err := df.Row(1)
+	require.NoError(t, err)
+	require.Equal(t, "two", row[col1].MustString())
+	require.Equal(t, "five", row[col2].MustString())
+	require.Equal(t, 6, row[col3].MustInt())
+	require.Equal(t, float64(9), row[col4].MustFloat())
+
+	_, err = df.Row(3)
+	require.IsType(t, Unknown{}, err)
+}
+
+func TestDataFrame_Rows(t *testing.T) {
+	rows := testDF().Rows(col3, col1)
+	var ints []int
+	var strs []string
+	for rows.HasNext() {
+		row := rows.Next()
+		ints = append(ints, row.NextInt())
+		strs = append(strs, row.NextString())
+		require.NoError(t, row.Error())
+	}
+	require.NoError(t, rows.Error())
+	require.Equal(t, []int{5, 6, 7}, ints)
+	require.Equal(t, []string{"one", "two", "three"}, strs)
+
+	rows = testDF().Rows("foo")
+	require.False(t, rows.HasNext())
+	require.IsType(t, Unknown{}, rows.Error())
+}
+
+func TestDataFrame_Filter(t *testing.T) {
+	df := testDF()
+	filtered, err := df.Filter(TruthFilter{true, false, true})
+	require.NoError(t, err)
+	require.Equal(t, 2, filtered.NRows())
+
+	val1, err := filtered.StringColumn(col1)
+	require.NoError(t, err)
+	require.Equal(t, NewStringSeries("one", "three"), val1)
+
+	val3, err := filtered.IntColumn(col3)
+	require.NoError(t, err)
+	require.Equal(t, NewIntSeries(5, 7), val3)
+
+	_, err = df.Filter(TruthFilter{true})
+	require.IsType(t, LengthMismatch{}, err)
+}
+
+func TestDataFrame_Where(t *testing.T) {
+	df := testDF()
+	filtered, err := df.Where(FloatCol(col4).GreaterThan(8).And(StringCol(col1).NotEqual("three")))
+	require.NoError(t, err)
+
+	val2, err := filtered.StringColumn(col2)
+	require.NoError(t, err)
+	require.Equal(t, NewStringSeries("five"), val2)
+
+	filtered, err = df.Where(IntCol(col3).SmallerThan(6).Or(IntCol(col3).GreaterThan(6)).Not())
+	require.NoError(t, err)
+	val4, err := filtered.FloatColumn(col4)
+	require.NoError(t, err)
+	require.Equal(t, NewFloatSeries(9), val4)
+
+	_, err = df.Where(FloatCol(col1).GreaterThan(8))
+	require.IsType(t, Unknown{}, err)
+}
+
+func TestDataFrame_FillNA(t *testing.T) {
+	df := testDF()
+	df, err := df.SetIntColumn(col3, NewIntSeries(5).AppendNull().Concat(NewIntSeries(7)))
+	require.NoError(t, err)
+	df, err = df.SetFloatColumn(col4, NewFloatSeries(8, 9).AppendNull())
+	require.NoError(t, err)
+
+	filled, err := df.FillNA(map[string]interface{}{col3: int64(0), col4: 1.5})
+	require.NoError(t, err)
+	val3, err := filled.IntColumn(col3)
+	require.NoError(t, err)
+	require.Equal(t, NewIntSeries(5, 0, 7), val3)
+	val4, err := filled.FloatColumn(col4)
+	require.NoError(t, err)
+	require.Equal(t, NewFloatSeries(8, 9, 1.5), val4)
+
+	_, err = df.FillNA(map[string]interface{}{col3: 0})
+	require.IsType(t, Unsupported{}, err)
+	_, err = df.FillNA(map[string]interface{}{"foo": 0})
+	require.IsType(t, Unknown{}, err)
+}
+
+func TestDataFrame_DropNA(t *testing.T) {
+	df := testDF()
+	df, err := df.SetIntColumn(col3, NewIntSeries(5).AppendNull().Concat(NewIntSeries(7)))
+	require.NoError(t, err)
+	df, err = df.SetFloatColumn(col4, NewFloatSeries(8, 9).AppendNull())
+	require.NoError(t, err)
+
+	dropped, err := df.DropNA()
+	require.NoError(t, err)
+	val1, err := dropped.StringColumn(col1)
+	require.NoError(t, err)
+	require.Equal(t, NewStringSeries("one"), val1)
+
+	dropped, err = df.DropNA(col4)
+	require.NoError(t, err)
+	require.Equal(t, 2, dropped.NRows())
+}
+
+func TestSeries_NullAggregates(t *testing.T) {
+	ints := NewIntSeries(4).AppendNull().Concat(NewIntSeries(2))
+	require.Equal(t, int64(6), Sum(ints))
+	require.Equal(t, float64(3), Avg(ints))
+	pos, min := Min(ints)
+	require.Equal(t, 2, pos)
+	require.Equal(t, int64(2), min)
+	require.Equal(t, TruthFilter{true, false, true}, ints.NotNull())
+	require.Equal(t, NewIntSeries(4, 2), ints.DropNA())
+
+	floats := NewFloatSeries().AppendNull()
+	pos, _ = Max(floats)
+	require.Equal(t, -1, pos)
+}
+
 const (
 	col1 = "col1"
 	col2 = "col2"
@@ -196,8 +458,8 @@ const (
 )
 
 var (
-	col1Val = NewStringSeries("one", "two")
-	col2Val = NewStringSeries("three", "four")
+	col1Val = NewStringSeries("one", "two", "three")
+	col2Val = NewStringSeries("four", "five", "six")
 	col3Val = NewIntSeries(5, 6, 7)
 	col4Val = NewFloatSeries(8, 9, 10)
 )
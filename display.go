@@ -0,0 +1,192 @@
+package godata
+
+import (
+	"github.com/tkhandel/go-data/element"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// displayRows is the number of rows String shows before eliding the middle of the frame
+	displayRows = 20
+	// displayWidth is the number of characters String shows of a value before cutting it short
+	displayWidth = 32
+)
+
+// Head returns the first n rows of the frame, or all of them when it has fewer.
+func (df DataFrame) Head(n int) DataFrame {
+	return df.take(positionRange(0, min(max(n, 0), df.NRows())))
+}
+
+// Tail returns the last n rows of the frame, or all of them when it has fewer.
+func (df DataFrame) Tail(n int) DataFrame {
+	return df.take(positionRange(max(df.NRows()-max(n, 0), 0), df.NRows()))
+}
+
+func positionRange(start, end int) []int {
+	positions := make([]int, 0, end-start)
+	for i := start; i < end; i++ {
+		positions = append(positions, i)
+	}
+	return positions
+}
+
+// String renders the frame as a table with the row positions, the column names and the column types under
+// them. Frames of more than 20 rows only show their first and last 10 rows, and values are cut short
+// beyond 32 characters.
+func (df DataFrame) String() string {
+	names := df.ColumnNames()
+	rows := positionRange(0, df.NRows())
+	if len(rows) > displayRows {
+		rows = append(rows[:displayRows/2], rows[len(rows)-displayRows/2:]...)
+	}
+
+	header := append([]string{""}, names...)
+	dtypes := []string{""}
+	rightAlign := []bool{true}
+	for _, name := range names {
+		dtypes = append(dtypes, df.columns[name].dType.String())
+		rightAlign = append(rightAlign, df.columns[name].dType != element.StringType)
+	}
+	var body [][]string
+	for k, i := range rows {
+		if k == displayRows/2 && len(rows) < df.NRows() {
+			elided := make([]string, len(header))
+			for j := range elided {
+				elided[j] = "..."
+			}
+			body = append(body, elided)
+		}
+		row := []string{strconv.Itoa(i)}
+		for _, name := range names {
+			row = append(row, shorten(df.display(name, i)))
+		}
+		body = append(body, row)
+	}
+
+	widths := make([]int, len(header))
+	for _, row := range append([][]string{header, dtypes}, body...) {
+		for j, cell := range row {
+			widths[j] = max(widths[j], utf8.RuneCountInString(cell))
+		}
+	}
+
+	var out strings.Builder
+	writeRule := func() {
+		for _, width := range widths {
+			out.WriteString("+" + strings.Repeat("-", width+2))
+		}
+		out.WriteString("+\n")
+	}
+	writeRow := func(row []string, aligned bool) {
+		for j, cell := range row {
+			pad := strings.Repeat(" ", widths[j]-utf8.RuneCountInString(cell))
+			if aligned && rightAlign[j] {
+				cell = pad + cell
+			} else {
+				cell += pad
+			}
+			out.WriteString("| " + cell + " ")
+		}
+		out.WriteString("|\n")
+	}
+
+	writeRule()
+	writeRow(header, false)
+	writeRow(dtypes, false)
+	writeRule()
+	for _, row := range body {
+		writeRow(row, true)
+	}
+	if len(body) > 0 {
+		writeRule()
+	}
+	out.WriteString(strconv.Itoa(df.NRows()) + " rows x " + strconv.Itoa(len(names)) + " columns\n")
+	return out.String()
+}
+
+// Markdown renders every row of the frame as a Markdown table. Use Head to show fewer rows.
+func (df DataFrame) Markdown() string {
+	names := df.ColumnNames()
+	var out strings.Builder
+	out.WriteString("|")
+	for _, name := range names {
+		out.WriteString(" " + escapeMarkdown(name) + " |")
+	}
+	out.WriteString("\n|")
+	for _, name := range names {
+		if df.columns[name].dType == element.StringType {
+			out.WriteString(" --- |")
+		} else {
+			out.WriteString(" ---: |")
+		}
+	}
+	out.WriteString("\n")
+	for i := 0; i < df.NRows(); i++ {
+		out.WriteString("|")
+		for _, name := range names {
+			out.WriteString(" " + escapeMarkdown(df.display(name, i)) + " |")
+		}
+		out.WriteString("\n")
+	}
+	return out.String()
+}
+
+func escapeMarkdown(val string) string {
+	return strings.ReplaceAll(val, "|", `\|`)
+}
+
+// HTML renders every row of the frame as an HTML table, with the column types in a second header row. Use Head
+// to show fewer rows.
+func (df DataFrame) HTML() string {
+	names := df.ColumnNames()
+	var out strings.Builder
+	out.WriteString("<table>\n<thead>\n<tr>")
+	for _, name := range names {
+		out.WriteString("<th>" + html.EscapeString(name) + "</th>")
+	}
+	out.WriteString("</tr>\n<tr>")
+	for _, name := range names {
+		out.WriteString("<th>" + df.columns[name].dType.String() + "</th>")
+	}
+	out.WriteString("</tr>\n</thead>\n<tbody>\n")
+	for i := 0; i < df.NRows(); i++ {
+		out.WriteString("<tr>")
+		for _, name := range names {
+			out.WriteString("<td>" + html.EscapeString(df.display(name, i)) + "</td>")
+		}
+		out.WriteString("</tr>\n")
+	}
+	out.WriteString("</tbody>\n</table>\n")
+	return out.String()
+}
+
+// display renders the value of column name at row i on a single line, with nulls and missing values as null.
+func (df DataFrame) display(name string, i int) string {
+	if i >= df.columnSize(name) {
+		return "null"
+	}
+	switch val := df.value(name, i).(type) {
+	case string:
+		return strings.NewReplacer("\n", `\n`, "\r", `\r`, "\t", `\t`).Replace(val)
+	case int64:
+		return strconv.FormatInt(val, 10)
+	case float64:
+		return strconv.FormatFloat(val, 'g', -1, 64)
+	case bool:
+		return strconv.FormatBool(val)
+	case time.Time:
+		return val.Format(time.RFC3339Nano)
+	}
+	return "null"
+}
+
+func shorten(val string) string {
+	if utf8.RuneCountInString(val) <= displayWidth {
+		return val
+	}
+	return string([]rune(val)[:displayWidth-3]) + "..."
+}
@@ -0,0 +1,83 @@
+package godata
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestDataFrame_HeadTail(t *testing.T) {
+	df := testDF()
+	head := df.Head(2)
+	require.Equal(t, 2, head.NRows())
+	ints, err := head.IntColumn(col3)
+	require.NoError(t, err)
+	require.Equal(t, NewIntSeries(5, 6), ints)
+
+	tail := df.Tail(2)
+	ints, err = tail.IntColumn(col3)
+	require.NoError(t, err)
+	require.Equal(t, NewIntSeries(6, 7), ints)
+
+	require.Equal(t, 3, df.Head(10).NRows())
+	require.Equal(t, 3, df.Tail(10).NRows())
+	require.Equal(t, 0, df.Head(-1).NRows())
+}
+
+func TestDataFrame_String(t *testing.T) {
+	df, err := CSV{HeadersPresent: true}.LoadCSV(strings.NewReader(testCSV + "a|b,,\n"))
+	require.NoError(t, err)
+	require.Equal(t, `+---+--------+---------+-------+
+|   | name   | qty     | price |
+|   | String | Integer | Float |
++---+--------+---------+-------+
+| 0 | apple  |       3 |   1.5 |
+| 1 | pear   |       4 |     2 |
+| 2 | plum   |    null |  0.25 |
+| 3 | a|b    |    null |  null |
++---+--------+---------+-------+
+4 rows x 3 columns
+`, fmt.Sprint(df))
+
+	long, err := NewDataFrame(NewStringColumn("text"))
+	require.NoError(t, err)
+	long, err = long.SetStringColumn("text", NewStringSeries(strings.Repeat("ab", 30)+"\n"))
+	require.NoError(t, err)
+	require.Contains(t, long.String(), "| 0 | "+strings.Repeat("ab", 14)+"a... |")
+
+	var data strings.Builder
+	data.WriteString("n\n")
+	for i := 0; i < 25; i++ {
+		fmt.Fprintf(&data, "%d\n", i)
+	}
+	big, err := CSV{HeadersPresent: true}.LoadCSV(strings.NewReader(data.String()))
+	require.NoError(t, err)
+	lines := strings.Split(big.String(), "\n")
+	require.Len(t, lines, 28)
+	require.Equal(t, "|   9 |       9 |", lines[13])
+	require.Equal(t, "| ... |     ... |", lines[14])
+	require.Equal(t, "|  15 |      15 |", lines[15])
+	require.Equal(t, "25 rows x 1 columns", lines[26])
+}
+
+func TestDataFrame_MarkdownHTML(t *testing.T) {
+	df, err := CSV{HeadersPresent: true}.LoadCSV(strings.NewReader("name,qty\na|b,3\n<i>,\n"))
+	require.NoError(t, err)
+	require.Equal(t, `| name | qty |
+| --- | ---: |
+| a\|b | 3 |
+| <i> | null |
+`, df.Markdown())
+	require.Equal(t, `<table>
+<thead>
+<tr><th>name</th><th>qty</th></tr>
+<tr><th>String</th><th>Integer</th></tr>
+</thead>
+<tbody>
+<tr><td>a|b</td><td>3</td></tr>
+<tr><td>&lt;i&gt;</td><td>null</td></tr>
+</tbody>
+</table>
+`, df.HTML())
+}
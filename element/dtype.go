@@ -7,6 +7,9 @@ const (
 	IntType
 	StringType
 	FloatType
+	BoolType
+	// TimeType values are time.Time, which carries its own location
+	TimeType
 )
 
 func (d Dtype) String() string {
@@ -17,6 +20,10 @@ func (d Dtype) String() string {
 		return "String"
 	case FloatType:
 		return "Float"
+	case BoolType:
+		return "Boolean"
+	case TimeType:
+		return "Time"
 	}
 	return ""
 }
@@ -3,6 +3,7 @@ package element
 import (
 	"fmt"
 	"github.com/pkg/errors"
+	"time"
 )
 
 type Element struct {
@@ -13,6 +14,11 @@ func New(value interface{}) Element {
 	return Element{value: value}
 }
 
+// IsNull reports whether the element stands for a missing value.
+func (e Element) IsNull() bool {
+	return e.value == nil
+}
+
 func (e Element) String() (string, error) {
 	val, ok := e.value.(string)
 	if !ok {
@@ -26,15 +32,21 @@ func (e Element) MustString() string {
 }
 
 func (e Element) Int() (int, error) {
-	val, ok := e.value.(int)
-	if !ok {
-		return 0, errors.New("invalid cast to int")
+	switch val := e.value.(type) {
+	case int:
+		return val, nil
+	case int64:
+		return int(val), nil
 	}
-	return val, nil
+	return 0, errors.New("invalid cast to int")
 }
 
 func (e Element) MustInt() int {
-	return e.value.(int)
+	val, err := e.Int()
+	if err != nil {
+		panic(err)
+	}
+	return val
 }
 
 func (e Element) Float() (float64, error) {
@@ -48,3 +60,27 @@ func (e Element) Float() (float64, error) {
 func (e Element) MustFloat() float64 {
 	return e.value.(float64)
 }
+
+func (e Element) Bool() (bool, error) {
+	val, ok := e.value.(bool)
+	if !ok {
+		return false, errors.New("invalid cast to bool")
+	}
+	return val, nil
+}
+
+func (e Element) MustBool() bool {
+	return e.value.(bool)
+}
+
+func (e Element) Time() (time.Time, error) {
+	val, ok := e.value.(time.Time)
+	if !ok {
+		return time.Time{}, errors.New("invalid cast to time")
+	}
+	return val, nil
+}
+
+func (e Element) MustTime() time.Time {
+	return e.value.(time.Time)
+}
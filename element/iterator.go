@@ -1,5 +1,7 @@
 package element
 
+import "time"
+
 type Iterator struct {
 	err  error
 	pos  int
@@ -15,7 +17,7 @@ func NewIterator(data []interface{}) *Iterator {
 }
 
 func (i *Iterator) HasNext() bool {
-	return i.err != nil && i.pos+1 < len(i.data)
+	return i.err == nil && i.pos+1 < len(i.data)
 }
 
 func (i *Iterator) NextInt() int {
@@ -45,6 +47,24 @@ func (i *Iterator) NextFloat() float64 {
 	return val
 }
 
+func (i *Iterator) NextBool() bool {
+	i.pos++
+	val, err := i.data[i.pos].Bool()
+	if err != nil {
+		i.err = err
+	}
+	return val
+}
+
+func (i *Iterator) NextTime() time.Time {
+	i.pos++
+	val, err := i.data[i.pos].Time()
+	if err != nil {
+		i.err = err
+	}
+	return val
+}
+
 func (i *Iterator) Error() error {
 	return i.err
 }
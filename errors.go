@@ -20,6 +20,25 @@ func (u Unknown) Error() string {
 	return fmt.Sprintf("unknown %s: %s", u.What, u.Value)
 }
 
+type Unsupported struct {
+	What  string
+	Value string
+}
+
+func (u Unsupported) Error() string {
+	return fmt.Sprintf("unsupported %s: %s", u.What, u.Value)
+}
+
+type LengthMismatch struct {
+	What     string
+	Expected int
+	Actual   int
+}
+
+func (l LengthMismatch) Error() string {
+	return fmt.Sprintf("length mismatch for %s: expected %d, got %d", l.What, l.Expected, l.Actual)
+}
+
 type ProcessingError struct {
 	Err error
 }
@@ -0,0 +1,307 @@
+package godata
+
+import (
+	"github.com/tkhandel/go-data/element"
+	"github.com/tkhandel/go-data/log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GroupedFrame holds the rows of a frame split by the distinct values of its key columns.
+type GroupedFrame struct {
+	df   DataFrame
+	keys []string
+	// groups holds the row positions of every group, in the order the groups first appear in the frame
+	groups [][]int
+}
+
+// GroupBy groups the rows of the frame on the values of the given columns, which cannot be floats. Times are grouped
+// by instant, whatever their location. Without any column,
+// all the rows form a single group. Rows with a null key do not belong to any group.
+func (df DataFrame) GroupBy(columns ...string) (GroupedFrame, error) {
+	for _, name := range columns {
+		col, ok := df.columns[name]
+		if !ok {
+			err := Unknown{What: "column", Value: name}
+			log.Get().Error(err.Error())
+			return GroupedFrame{}, err
+		}
+		if col.dType == element.FloatType {
+			err := Unsupported{What: "group key type", Value: col.dType.String()}
+			log.Get().Error(err.Error())
+			return GroupedFrame{}, err
+		}
+	}
+
+	grouped := GroupedFrame{df: df, keys: columns}
+	positions := make(map[string]int)
+	for i := 0; i < df.NRows(); i++ {
+		key, ok := df.groupKey(columns, i)
+		if !ok {
+			continue
+		}
+		pos, ok := positions[key]
+		if !ok {
+			pos = len(grouped.groups)
+			positions[key] = pos
+			grouped.groups = append(grouped.groups, nil)
+		}
+		grouped.groups[pos] = append(grouped.groups[pos], i)
+	}
+	return grouped, nil
+}
+
+// groupKey encodes the values of the key columns at row i so that distinct combinations never collide.
+// It reports false when one of the values is null.
+func (df DataFrame) groupKey(columns []string, i int) (string, bool) {
+	var key strings.Builder
+	for _, name := range columns {
+		if !df.valid(name, i) {
+			return "", false
+		}
+		switch series := df.series[name].(type) {
+		case StringSeries:
+			key.WriteString(strconv.Quote(series.Index(i)))
+		case IntSeries:
+			key.WriteString(strconv.FormatInt(series.Index(i), 10))
+		case BoolSeries:
+			key.WriteString(strconv.FormatBool(series.Index(i)))
+		case TimeSeries:
+			key.WriteString(series.Index(i).UTC().Format(time.RFC3339Nano))
+		}
+		key.WriteByte(',')
+	}
+	return key.String(), true
+}
+
+func (g GroupedFrame) NGroups() int {
+	return len(g.groups)
+}
+
+// Agg returns a frame with one row per group, holding the key columns followed by one column per aggregation.
+func (g GroupedFrame) Agg(aggs ...Aggregation) (DataFrame, error) {
+	var columns []Column
+	for _, key := range g.keys {
+		columns = append(columns, g.df.columns[key])
+	}
+	outTypes := make([]element.Dtype, len(aggs))
+	for j, agg := range aggs {
+		col, ok := g.df.columns[agg.column]
+		if !ok {
+			err := Unknown{What: "column", Value: agg.column}
+			log.Get().Error(err.Error())
+			return DataFrame{}, err
+		}
+		dType, err := agg.dType(col.dType)
+		if err != nil {
+			log.Get().Error(err.Error())
+			return DataFrame{}, err
+		}
+		outTypes[j] = dType
+		columns = append(columns, Column{name: agg.name(), dType: dType})
+	}
+
+	out, err := NewDataFrame(columns...)
+	if err != nil {
+		return DataFrame{}, err
+	}
+
+	firsts := make([]int, len(g.groups))
+	for k, rows := range g.groups {
+		firsts[k] = rows[0]
+	}
+	for _, key := range g.keys {
+		if out, err = out.setValues(key, g.df.columns[key].dType, g.df.takeValues(key, firsts)); err != nil {
+			return DataFrame{}, err
+		}
+	}
+
+	for j, agg := range aggs {
+		values := make([]interface{}, len(g.groups))
+		for k, rows := range g.groups {
+			values[k] = agg.reduce(g.df, agg.column, rows)
+		}
+		if out, err = out.setValues(agg.name(), outTypes[j], values); err != nil {
+			return DataFrame{}, err
+		}
+	}
+	return out, nil
+}
+
+// AggNumeric applies agg to every int and float column that is not a key, keeping the names of the columns, e.g.
+// g.AggNumeric(AggMean).
+func (g GroupedFrame) AggNumeric(agg func(column string) Aggregation) (DataFrame, error) {
+	keys := make(map[string]bool)
+	for _, key := range g.keys {
+		keys[key] = true
+	}
+
+	var aggs []Aggregation
+	for _, name := range g.df.ColumnNames() {
+		if _, err := numericType(g.df.columns[name].dType); err == nil && !keys[name] {
+			aggs = append(aggs, agg(name).As(name))
+		}
+	}
+	return g.Agg(aggs...)
+}
+
+// Aggregation reduces the values of a column within each group to a single value.
+type Aggregation struct {
+	column string
+	as     string
+	fn     string
+	dType  func(element.Dtype) (element.Dtype, error)
+	reduce func(df DataFrame, column string, rows []int) interface{}
+}
+
+// As names the column holding the aggregated values. It defaults to the column name and the aggregation
+// joined by an underscore, e.g. price_sum.
+func (a Aggregation) As(name string) Aggregation {
+	a.as = name
+	return a
+}
+
+func (a Aggregation) name() string {
+	if a.as != "" {
+		return a.as
+	}
+	return a.column + "_" + a.fn
+}
+
+// AggSum, AggMean, AggMin and AggMax skip the nulls. The last three give a null for a group without values.
+func AggSum(column string) Aggregation {
+	return Aggregation{column: column, fn: "sum", dType: numericType, reduce: func(df DataFrame, column string, rows []int) interface{} {
+		switch series := df.series[column].(type) {
+		case IntSeries:
+			return Sum(series.take(rows))
+		case FloatSeries:
+			return Sum(series.take(rows))
+		}
+		return nil
+	}}
+}
+
+func AggMean(column string) Aggregation {
+	return Aggregation{column: column, fn: "mean", dType: floatType, reduce: func(df DataFrame, column string, rows []int) interface{} {
+		if !df.anyValid(column, rows) {
+			return nil
+		}
+		switch series := df.series[column].(type) {
+		case IntSeries:
+			return Avg(series.take(rows))
+		case FloatSeries:
+			return Avg(series.take(rows))
+		}
+		return nil
+	}}
+}
+
+func AggMin(column string) Aggregation {
+	return Aggregation{column: column, fn: "min", dType: numericType, reduce: func(df DataFrame, column string, rows []int) interface{} {
+		switch series := df.series[column].(type) {
+		case IntSeries:
+			if pos, min := Min(series.take(rows)); pos >= 0 {
+				return min
+			}
+		case FloatSeries:
+			if pos, min := Min(series.take(rows)); pos >= 0 {
+				return min
+			}
+		}
+		return nil
+	}}
+}
+
+func AggMax(column string) Aggregation {
+	return Aggregation{column: column, fn: "max", dType: numericType, reduce: func(df DataFrame, column string, rows []int) interface{} {
+		switch series := df.series[column].(type) {
+		case IntSeries:
+			if pos, max := Max(series.take(rows)); pos >= 0 {
+				return max
+			}
+		case FloatSeries:
+			if pos, max := Max(series.take(rows)); pos >= 0 {
+				return max
+			}
+		}
+		return nil
+	}}
+}
+
+// AggCount counts the values that are not null.
+func AggCount(column string) Aggregation {
+	return Aggregation{column: column, fn: "count", dType: func(element.Dtype) (element.Dtype, error) {
+		return element.IntType, nil
+	}, reduce: func(df DataFrame, column string, rows []int) interface{} {
+		count := int64(0)
+		for _, i := range rows {
+			if df.valid(column, i) {
+				count++
+			}
+		}
+		return count
+	}}
+}
+
+func AggFirst(column string) Aggregation {
+	return Aggregation{column: column, fn: "first", dType: sameType, reduce: func(df DataFrame, column string, rows []int) interface{} {
+		return df.value(column, rows[0])
+	}}
+}
+
+func AggLast(column string) Aggregation {
+	return Aggregation{column: column, fn: "last", dType: sameType, reduce: func(df DataFrame, column string, rows []int) interface{} {
+		return df.value(column, rows[len(rows)-1])
+	}}
+}
+
+// AggIntFunc reduces the values of an int column in each group with a custom function.
+func AggIntFunc(column string, as string, reduce func(IntSeries) float64) Aggregation {
+	return Aggregation{column: column, as: as, fn: "custom", dType: onlyType(element.IntType), reduce: func(df DataFrame, column string, rows []int) interface{} {
+		return reduce(df.series[column].(IntSeries).take(rows))
+	}}
+}
+
+// AggFloatFunc reduces the values of a float column in each group with a custom function.
+func AggFloatFunc(column string, as string, reduce func(FloatSeries) float64) Aggregation {
+	return Aggregation{column: column, as: as, fn: "custom", dType: onlyType(element.FloatType), reduce: func(df DataFrame, column string, rows []int) interface{} {
+		return reduce(df.series[column].(FloatSeries).take(rows))
+	}}
+}
+
+func (df DataFrame) anyValid(column string, rows []int) bool {
+	for _, i := range rows {
+		if df.valid(column, i) {
+			return true
+		}
+	}
+	return false
+}
+
+func numericType(dType element.Dtype) (element.Dtype, error) {
+	if dType != element.IntType && dType != element.FloatType {
+		return 0, Unsupported{What: "aggregation of column type", Value: dType.String()}
+	}
+	return dType, nil
+}
+
+func floatType(dType element.Dtype) (element.Dtype, error) {
+	if _, err := numericType(dType); err != nil {
+		return 0, err
+	}
+	return element.FloatType, nil
+}
+
+func sameType(dType element.Dtype) (element.Dtype, error) {
+	return dType, nil
+}
+
+func onlyType(accepted element.Dtype) func(element.Dtype) (element.Dtype, error) {
+	return func(dType element.Dtype) (element.Dtype, error) {
+		if dType != accepted {
+			return 0, Unsupported{What: "aggregation of column type", Value: dType.String()}
+		}
+		return element.FloatType, nil
+	}
+}
@@ -0,0 +1,81 @@
+package godata
+
+import (
+	"github.com/stretchr/testify/require"
+	"github.com/tkhandel/go-data/element"
+	"strings"
+	"testing"
+)
+
+const testSalesCSV = `region,year,qty,price
+EU,2019,3,1.5
+US,2019,4,2
+EU,2019,5,3.5
+EU,2020,1,4
+`
+
+func TestDataFrame_GroupBy_Agg(t *testing.T) {
+	df, err := CSV{HeadersPresent: true}.LoadCSV(strings.NewReader(testSalesCSV))
+	require.NoError(t, err)
+
+	grouped, err := df.GroupBy("region", "year")
+	require.NoError(t, err)
+	require.Equal(t, 3, grouped.NGroups())
+
+	out, err := grouped.Agg(
+		AggSum("qty"),
+		AggMean("price").As("avg_price"),
+		AggMin("price"),
+		AggMax("qty"),
+		AggCount("qty"),
+		AggFirst("price"),
+		AggLast("price"),
+		AggIntFunc("qty", "qty_range", func(s IntSeries) float64 {
+			_, min := Min(s)
+			_, max := Max(s)
+			return float64(max - min)
+		}),
+	)
+	require.NoError(t, err)
+	require.Equal(t, 3, out.NRows())
+
+	region, _ := out.StringColumn("region")
+	require.Equal(t, NewStringSeries("EU", "US", "EU"), region)
+	year, _ := out.IntColumn("year")
+	require.Equal(t, NewIntSeries(2019, 2019, 2020), year)
+	sum, _ := out.IntColumn("qty_sum")
+	require.Equal(t, NewIntSeries(8, 4, 1), sum)
+	mean, _ := out.FloatColumn("avg_price")
+	require.Equal(t, NewFloatSeries(2.5, 2, 4), mean)
+	min, _ := out.FloatColumn("price_min")
+	require.Equal(t, NewFloatSeries(1.5, 2, 4), min)
+	max, _ := out.IntColumn("qty_max")
+	require.Equal(t, NewIntSeries(5, 4, 1), max)
+	count, _ := out.IntColumn("qty_count")
+	require.Equal(t, NewIntSeries(2, 1, 1), count)
+	first, _ := out.FloatColumn("price_first")
+	require.Equal(t, NewFloatSeries(1.5, 2, 4), first)
+	last, _ := out.FloatColumn("price_last")
+	require.Equal(t, NewFloatSeries(3.5, 2, 4), last)
+	qtyRange, _ := out.FloatColumn("qty_range")
+	require.Equal(t, NewFloatSeries(2, 0, 0), qtyRange)
+}
+
+func TestDataFrame_GroupBy_Errors(t *testing.T) {
+	df, err := CSV{HeadersPresent: true}.LoadCSV(strings.NewReader(testSalesCSV))
+	require.NoError(t, err)
+
+	_, err = df.GroupBy("price")
+	require.IsType(t, Unsupported{}, err)
+	require.Equal(t, element.FloatType.String(), err.(Unsupported).Value)
+
+	_, err = df.GroupBy("foo")
+	require.IsType(t, Unknown{}, err)
+
+	grouped, err := df.GroupBy("region")
+	require.NoError(t, err)
+	_, err = grouped.Agg(AggSum("region"))
+	require.IsType(t, Unsupported{}, err)
+	_, err = grouped.Agg(AggFloatFunc("qty", "x", func(FloatSeries) float64 { return 0 }))
+	require.IsType(t, Unsupported{}, err)
+}
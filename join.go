@@ -0,0 +1,174 @@
+package godata
+
+import (
+	"github.com/tkhandel/go-data/element"
+	"github.com/tkhandel/go-data/log"
+	"strconv"
+)
+
+type JoinType int
+
+const (
+	// InnerJoin keeps the rows whose keys are found in both frames.
+	InnerJoin JoinType = iota
+	// LeftJoin keeps every row of the left frame, with nulls where the right frame has no match.
+	LeftJoin
+	// RightJoin keeps every row of the right frame, with nulls where the left frame has no match.
+	RightJoin
+	// OuterJoin keeps every row of both frames, with nulls on the side that has no match.
+	OuterJoin
+)
+
+// Join combines the rows of the two frames whose values in the key columns are equal. The result holds the key
+// columns followed by the other columns of df and then those of other. Non-key columns present in both frames
+// get the suffixes "_left" and "_right"; JoinSuffixes chooses other suffixes.
+// Null keys never match.
+func (df DataFrame) Join(other DataFrame, on []string, how JoinType) (DataFrame, error) {
+	return df.JoinSuffixes(other, on, how, "_left", "_right")
+}
+
+func (df DataFrame) JoinSuffixes(other DataFrame, on []string, how JoinType, leftSuffix, rightSuffix string) (DataFrame, error) {
+	isKey := make(map[string]bool)
+	for _, name := range on {
+		if err := df.checkJoinKey(other, name); err != nil {
+			log.Get().Error(err.Error())
+			return DataFrame{}, err
+		}
+		isKey[name] = true
+	}
+
+	var leftPos, rightPos []int
+	switch how {
+	case InnerJoin, LeftJoin, OuterJoin:
+		leftPos, rightPos = hashJoin(df, other, on, how != InnerJoin, how == OuterJoin)
+	case RightJoin:
+		rightPos, leftPos = hashJoin(other, df, on, true, false)
+	default:
+		err := Unknown{What: "join type", Value: strconv.Itoa(int(how))}
+		log.Get().Error(err.Error())
+		return DataFrame{}, err
+	}
+
+	var columns []Column
+	for _, name := range on {
+		columns = append(columns, df.columns[name])
+	}
+	leftNames := make(map[string]string)
+	for _, name := range df.ColumnNames() {
+		if isKey[name] {
+			continue
+		}
+		leftNames[name] = name
+		if _, clash := other.columns[name]; clash {
+			leftNames[name] = name + leftSuffix
+		}
+		columns = append(columns, Column{name: leftNames[name], dType: df.columns[name].dType})
+	}
+	rightNames := make(map[string]string)
+	for _, name := range other.ColumnNames() {
+		if isKey[name] {
+			continue
+		}
+		rightNames[name] = name
+		if _, clash := df.columns[name]; clash {
+			rightNames[name] = name + rightSuffix
+		}
+		columns = append(columns, Column{name: rightNames[name], dType: other.columns[name].dType})
+	}
+
+	joined, err := NewDataFrame(columns...)
+	if err != nil {
+		log.Get().Error(err.Error())
+		return DataFrame{}, err
+	}
+
+	// Key values come from the left frame, or from the right one for the rows only found there
+	keyPos := make([]int, len(leftPos))
+	for k := range leftPos {
+		keyPos[k] = leftPos[k]
+		if keyPos[k] < 0 {
+			keyPos[k] = df.NRows() + rightPos[k]
+		}
+	}
+	for _, name := range on {
+		keys := df.series[name].concatAny(other.series[name]).takeAny(keyPos)
+		if joined, err = joined.setSeries(name, keys); err != nil {
+			return DataFrame{}, err
+		}
+	}
+
+	for name, as := range leftNames {
+		if joined, err = joined.setTaken(df, name, as, leftPos); err != nil {
+			return DataFrame{}, err
+		}
+	}
+	for name, as := range rightNames {
+		if joined, err = joined.setTaken(other, name, as, rightPos); err != nil {
+			return DataFrame{}, err
+		}
+	}
+	return joined, nil
+}
+
+func (df DataFrame) checkJoinKey(other DataFrame, name string) error {
+	col, ok := df.columns[name]
+	if !ok {
+		return Unknown{What: "column", Value: name}
+	}
+	otherCol, ok := other.columns[name]
+	if !ok {
+		return Unknown{What: "column", Value: name}
+	}
+	if col.dType == element.FloatType {
+		return Unsupported{What: "join key type", Value: col.dType.String()}
+	}
+	if col.dType != otherCol.dType {
+		return Unsupported{What: "join key type", Value: col.dType.String() + " and " + otherCol.dType.String()}
+	}
+	return nil
+}
+
+// hashJoin pairs the rows of probe with the matching rows of build, in the order of the rows of probe.
+// With keepProbe, rows of probe without a match are paired with -1; with keepBuild, the rows of build that
+// were never matched are added at the end, paired with -1.
+func hashJoin(probe, build DataFrame, on []string, keepProbe, keepBuild bool) (probePos, buildPos []int) {
+	index := make(map[string][]int)
+	for i := 0; i < build.NRows(); i++ {
+		if key, ok := build.groupKey(on, i); ok {
+			index[key] = append(index[key], i)
+		}
+	}
+
+	matched := make([]bool, build.NRows())
+	for i := 0; i < probe.NRows(); i++ {
+		var matches []int
+		if key, ok := probe.groupKey(on, i); ok {
+			matches = index[key]
+		}
+		for _, j := range matches {
+			probePos = append(probePos, i)
+			buildPos = append(buildPos, j)
+			matched[j] = true
+		}
+		if len(matches) == 0 && keepProbe {
+			probePos = append(probePos, i)
+			buildPos = append(buildPos, -1)
+		}
+	}
+
+	if keepBuild {
+		for j, ok := range matched {
+			if !ok {
+				probePos = append(probePos, -1)
+				buildPos = append(buildPos, j)
+			}
+		}
+	}
+	return probePos, buildPos
+}
+
+// setTaken stores the values of column name of src at the given positions as column as. Negative positions
+// give nulls.
+func (df DataFrame) setTaken(src DataFrame, name, as string, positions []int) (DataFrame, error) {
+	return df.setSeries(as, src.series[name].takeAny(positions))
+}
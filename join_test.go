@@ -0,0 +1,84 @@
+package godata
+
+import (
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func joinTestFrames(t *testing.T) (DataFrame, DataFrame) {
+	c := CSV{HeadersPresent: true}
+	orders, err := c.LoadCSV(strings.NewReader("id,qty,note\n1,3,a\n2,4,b\n2,5,c\n3,6,d\n"))
+	require.NoError(t, err)
+	products, err := c.LoadCSV(strings.NewReader("id,price,note\n2,1.5,x\n3,2.5,y\n4,3.5,z\n"))
+	require.NoError(t, err)
+	return orders, products
+}
+
+func TestDataFrame_Join_Inner(t *testing.T) {
+	orders, products := joinTestFrames(t)
+	joined, err := orders.Join(products, []string{"id"}, InnerJoin)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []Column{
+		NewIntColumn("id"),
+		NewIntColumn("qty"),
+		NewStringColumn("note_left"),
+		NewFloatColumn("price"),
+		NewStringColumn("note_right"),
+	}, joined.Columns())
+
+	id, _ := joined.IntColumn("id")
+	require.Equal(t, NewIntSeries(2, 2, 3), id)
+	price, _ := joined.FloatColumn("price")
+	require.Equal(t, NewFloatSeries(1.5, 1.5, 2.5), price)
+	note, _ := joined.StringColumn("note_left")
+	require.Equal(t, NewStringSeries("b", "c", "d"), note)
+}
+
+func TestDataFrame_Join_Left(t *testing.T) {
+	orders, products := joinTestFrames(t)
+	joined, err := orders.JoinSuffixes(products, []string{"id"}, LeftJoin, "_o", "_p")
+	require.NoError(t, err)
+
+	id, _ := joined.IntColumn("id")
+	require.Equal(t, NewIntSeries(1, 2, 2, 3), id)
+	price, _ := joined.FloatColumn("price")
+	require.False(t, price.Valid(0))
+	require.True(t, price.Valid(1))
+	note, _ := joined.StringColumn("note_p")
+	require.Equal(t, "", note.Index(0))
+	require.Equal(t, "x", note.Index(1))
+}
+
+func TestDataFrame_Join_RightAndOuter(t *testing.T) {
+	orders, products := joinTestFrames(t)
+	joined, err := orders.Join(products, []string{"id"}, RightJoin)
+	require.NoError(t, err)
+	id, _ := joined.IntColumn("id")
+	require.Equal(t, NewIntSeries(2, 2, 3, 4), id)
+	qty, _ := joined.IntColumn("qty")
+	require.True(t, qty.Valid(2))
+	require.False(t, qty.Valid(3))
+
+	joined, err = orders.Join(products, []string{"id"}, OuterJoin)
+	require.NoError(t, err)
+	id, _ = joined.IntColumn("id")
+	require.Equal(t, NewIntSeries(1, 2, 2, 3, 4), id)
+	price, _ := joined.FloatColumn("price")
+	require.False(t, price.Valid(0))
+	require.False(t, qty.Valid(3))
+	require.Equal(t, 3.5, price.Index(4))
+}
+
+func TestDataFrame_Join_Errors(t *testing.T) {
+	orders, products := joinTestFrames(t)
+	_, err := orders.Join(products, []string{"qty"}, InnerJoin)
+	require.IsType(t, Unknown{}, err)
+
+	_, err = orders.Join(products, []string{"id"}, JoinType(9))
+	require.IsType(t, Unknown{}, err)
+
+	orders, _ = orders.SetStringColumn("price", NewStringSeries("a", "b", "c", "d"))
+	_, err = orders.Join(products, []string{"price"}, InnerJoin)
+	require.IsType(t, Unsupported{}, err)
+}
@@ -0,0 +1,441 @@
+package godata
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"github.com/tkhandel/go-data/element"
+	"github.com/tkhandel/go-data/log"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JSONOrient is the layout of a frame in JSON.
+type JSONOrient int
+
+const (
+	// RecordsOrient holds an array with an object per row, e.g. [{"a":1,"b":"x"},{"a":2,"b":"y"}].
+	RecordsOrient JSONOrient = iota
+	// ColumnsOrient holds an object with an array per column, e.g. {"a":[1,2],"b":["x","y"]}.
+	ColumnsOrient
+	// LinesOrient holds an object per row on its own line, also known as NDJSON.
+	LinesOrient
+)
+
+type JSON struct {
+	Orient JSONOrient
+	// Dtypes overrides the inferred type of the named columns.
+	Dtypes map[string]element.Dtype
+	// TimeLayout is the layout, as understood by time.Parse, used to read and write time columns. When set,
+	// columns whose values are all strings that parse with it are inferred as times. The empty layout means
+	// time.RFC3339Nano and leaves inference off.
+	TimeLayout string
+}
+
+// LoadJSON reads a frame laid out as Orient. Columns of numbers are inferred as ints when every number is
+// an int and floats otherwise, columns of booleans as bools and columns of strings as strings. Columns mixing
+// kinds of values, or holding arrays or objects, are loaded as strings holding the JSON text of the values.
+// Missing values, nulls and columns are loaded as nulls.
+func (j JSON) LoadJSON(rdr io.Reader) (DataFrame, error) {
+	dec := json.NewDecoder(rdr)
+	dec.UseNumber()
+
+	values := jsonColumns{index: make(map[string]int)}
+	var err error
+	switch j.Orient {
+	case RecordsOrient:
+		err = readJSONArray(dec, func() error { return values.readRecord(dec) })
+	case ColumnsOrient:
+		err = readJSONObject(dec, func(name string) error {
+			if _, ok := values.index[name]; ok {
+				return Duplicate{What: "key", Value: name}
+			}
+			values.column(name)
+			return readJSONArray(dec, func() error { return values.read(dec, name, -1) })
+		})
+	case LinesOrient:
+		for dec.More() && err == nil {
+			err = values.readRecord(dec)
+		}
+	default:
+		err = Unsupported{What: "JSON orientation", Value: strconv.Itoa(int(j.Orient))}
+	}
+	if err != nil {
+		if _, ok := err.(Unsupported); !ok {
+			err = ProcessingError{Err: errors.Wrap(err, "reading JSON")}
+		}
+		log.Get().Error(err.Error())
+		return DataFrame{}, err
+	}
+	return j.frame(values)
+}
+
+// frame types the values read and sets them on a new frame.
+func (j JSON) frame(values jsonColumns) (DataFrame, error) {
+	reader := CSV{Strict: true, TimeLayout: j.TimeLayout}
+	columns := make([]Column, len(values.names))
+	builders := make([]*columnBuilder, len(values.names))
+	for k, name := range values.names {
+		dType, ok := j.Dtypes[name]
+		if !ok {
+			dType = reader.inferJSONDtype(values.values[k])
+		}
+		columns[k] = Column{name: name, dType: dType}
+		builders[k] = reader.newColumnBuilder(columns[k], len(values.values[k]), nil)
+	}
+
+	df, err := NewDataFrame(columns...)
+	if err != nil {
+		return DataFrame{}, err
+	}
+	for k, builder := range builders {
+		for row, val := range values.values[k] {
+			if err := builder.appendJSON(val, row); err != nil {
+				log.Get().Error(err.Error())
+				return DataFrame{}, err
+			}
+		}
+		for row := len(values.values[k]); row < values.rows; row++ {
+			builder.appendJSON(jsonValue{}, row)
+		}
+		if df, err = builder.setOn(df); err != nil {
+			log.Get().Error(err.Error())
+			return DataFrame{}, err
+		}
+	}
+	return df, nil
+}
+
+// jsonValue is a value read from JSON along with its kind, which is one of the first bytes of null, true or
+// false, numbers and strings, or zero for arrays, objects and missing values.
+type jsonValue struct {
+	kind byte
+	text string
+}
+
+func newJSONValue(raw json.RawMessage) (jsonValue, error) {
+	raw = bytes.TrimSpace(raw)
+	switch {
+	case len(raw) == 0:
+		return jsonValue{kind: 'n'}, nil
+	case raw[0] == '"':
+		var text string
+		err := json.Unmarshal(raw, &text)
+		return jsonValue{kind: '"', text: text}, err
+	case raw[0] == 't' || raw[0] == 'f':
+		return jsonValue{kind: 't', text: string(raw)}, nil
+	case raw[0] == 'n':
+		return jsonValue{kind: 'n'}, nil
+	case raw[0] == '[' || raw[0] == '{':
+		return jsonValue{kind: '{', text: string(raw)}, nil
+	}
+	return jsonValue{kind: '0', text: string(raw)}, nil
+}
+
+func (v jsonValue) null() bool {
+	return v.kind == 'n' || v.kind == 0
+}
+
+// jsonColumns gathers the values read by column, in the order the columns first appear.
+type jsonColumns struct {
+	names  []string
+	index  map[string]int
+	values [][]jsonValue
+	rows   int
+}
+
+func (c *jsonColumns) readRecord(dec *json.Decoder) error {
+	err := readJSONObject(dec, func(name string) error { return c.read(dec, name, c.rows) })
+	c.rows++
+	return err
+}
+
+// read decodes the next value of column name. A non-negative row places it at that row, padding with nulls, and
+// a row cannot have two values of a column.
+func (c *jsonColumns) read(dec *json.Decoder, name string, row int) error {
+	var raw json.RawMessage
+	if err := dec.Decode(&raw); err != nil {
+		return err
+	}
+	val, err := newJSONValue(raw)
+	if err != nil {
+		return err
+	}
+
+	k := c.column(name)
+	if row >= 0 && len(c.values[k]) > row {
+		return Duplicate{What: "key in row " + strconv.Itoa(row), Value: name}
+	}
+	for row >= 0 && len(c.values[k]) < row {
+		c.values[k] = append(c.values[k], jsonValue{})
+	}
+	c.values[k] = append(c.values[k], val)
+	if row < 0 && len(c.values[k]) > c.rows {
+		c.rows = len(c.values[k])
+	}
+	return nil
+}
+
+// column returns the position of column name, adding it when it was not read yet.
+func (c *jsonColumns) column(name string) int {
+	k, ok := c.index[name]
+	if !ok {
+		k = len(c.names)
+		c.index[name] = k
+		c.names = append(c.names, name)
+		c.values = append(c.values, nil)
+	}
+	return k
+}
+
+func readJSONArray(dec *json.Decoder, readItem func() error) error {
+	if err := expectJSONDelim(dec, '['); err != nil {
+		return err
+	}
+	for dec.More() {
+		if err := readItem(); err != nil {
+			return err
+		}
+	}
+	_, err := dec.Token()
+	return err
+}
+
+func readJSONObject(dec *json.Decoder, readValue func(key string) error) error {
+	if err := expectJSONDelim(dec, '{'); err != nil {
+		return err
+	}
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if err := readValue(token.(string)); err != nil {
+			return err
+		}
+	}
+	_, err := dec.Token()
+	return err
+}
+
+func expectJSONDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return errors.Errorf("expected %s, got %v", delim, token)
+	}
+	return nil
+}
+
+// inferJSONDtype picks the type of a column from the kinds of its values, leaving out nulls.
+func (c CSV) inferJSONDtype(values []jsonValue) element.Dtype {
+	var kind byte
+	var texts [][]string
+	for _, val := range values {
+		if val.null() {
+			continue
+		}
+		if kind != 0 && kind != val.kind {
+			return element.StringType
+		}
+		kind = val.kind
+		texts = append(texts, []string{val.text})
+	}
+
+	switch kind {
+	case '0':
+		if dType := c.inferDtype(texts, 0, nil); dType == element.IntType {
+			return dType
+		}
+		return element.FloatType
+	case 't':
+		return element.BoolType
+	case '"':
+		if c.TimeLayout != "" && c.inferDtype(texts, 0, nil) == element.TimeType {
+			return element.TimeType
+		}
+	}
+	return element.StringType
+}
+
+func (b *columnBuilder) appendJSON(val jsonValue, row int) error {
+	if val.null() {
+		return b.values.append("", false)
+	}
+	if err := b.values.append(val.text, true); err != nil {
+		return b.parseError(err, row)
+	}
+	return nil
+}
+
+// WriteJSON writes every column of the frame in its order, laid out as Orient. Nulls, and NaN or infinite
+// floats, are written as null. Floats always have a fractional part or an exponent, so they read back as floats.
+func (j JSON) WriteJSON(wrt io.Writer, df DataFrame) error {
+	columns := df.ColumnNames()
+	buf := bufio.NewWriter(wrt)
+	switch j.Orient {
+	case RecordsOrient:
+		buf.WriteByte('[')
+		for i := 0; i < df.NRows(); i++ {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			j.writeRecord(buf, df, columns, i)
+		}
+		buf.WriteString("]\n")
+	case ColumnsOrient:
+		buf.WriteByte('{')
+		for k, name := range columns {
+			if k > 0 {
+				buf.WriteByte(',')
+			}
+			writeJSONString(buf, name)
+			buf.WriteString(":[")
+			for i := 0; i < df.NRows(); i++ {
+				if i > 0 {
+					buf.WriteByte(',')
+				}
+				j.writeValue(buf, df, name, i)
+			}
+			buf.WriteByte(']')
+		}
+		buf.WriteString("}\n")
+	case LinesOrient:
+		for i := 0; i < df.NRows(); i++ {
+			j.writeRecord(buf, df, columns, i)
+			buf.WriteByte('\n')
+		}
+	default:
+		err := Unsupported{What: "JSON orientation", Value: strconv.Itoa(int(j.Orient))}
+		log.Get().Error(err.Error())
+		return err
+	}
+
+	if err := buf.Flush(); err != nil {
+		writeErr := ProcessingError{Err: errors.Wrap(err, "writing JSON")}
+		log.Get().Error(writeErr.Error())
+		return writeErr
+	}
+	return nil
+}
+
+// WriteNDJSON writes the frame as newline-delimited JSON, whatever Orient is.
+func (j JSON) WriteNDJSON(wrt io.Writer, df DataFrame) error {
+	j.Orient = LinesOrient
+	return j.WriteJSON(wrt, df)
+}
+
+func (j JSON) writeRecord(buf *bufio.Writer, df DataFrame, columns []string, i int) {
+	buf.WriteByte('{')
+	for k, name := range columns {
+		if k > 0 {
+			buf.WriteByte(',')
+		}
+		writeJSONString(buf, name)
+		buf.WriteByte(':')
+		j.writeValue(buf, df, name, i)
+	}
+	buf.WriteByte('}')
+}
+
+func (j JSON) writeValue(buf *bufio.Writer, df DataFrame, name string, i int) {
+	series := df.series[name]
+	if i >= series.Size() {
+		buf.WriteString("null")
+		return
+	}
+	switch val := series.value(i).(type) {
+	case string:
+		writeJSONString(buf, val)
+	case int64:
+		buf.WriteString(strconv.FormatInt(val, 10))
+	case float64:
+		if math.IsNaN(val) || math.IsInf(val, 0) {
+			buf.WriteString("null")
+			return
+		}
+		text := strconv.FormatFloat(val, 'g', -1, 64)
+		if !strings.ContainsAny(text, ".e") {
+			text += ".0"
+		}
+		buf.WriteString(text)
+	case bool:
+		buf.WriteString(strconv.FormatBool(val))
+	case time.Time:
+		layout := j.TimeLayout
+		if layout == "" {
+			layout = time.RFC3339Nano
+		}
+		writeJSONString(buf, val.Format(layout))
+	default:
+		buf.WriteString("null")
+	}
+}
+
+func writeJSONString(buf *bufio.Writer, val string) {
+	text, _ := json.Marshal(val)
+	buf.Write(text)
+}
+
+// jsonFrame is the form frames are marshalled in: the columns laid out as by ColumnsOrient, along with the
+// types of the columns so that they are read back as they were.
+type jsonFrame struct {
+	Dtypes  map[string]string `json:"dtypes"`
+	Columns json.RawMessage   `json:"columns"`
+}
+
+// MarshalJSON writes the frame laid out as columns along with the types of the columns, which keeps the columns
+// of frames without rows and of columns of nulls.
+func (df DataFrame) MarshalJSON() ([]byte, error) {
+	var out bytes.Buffer
+	if err := (JSON{Orient: ColumnsOrient}).WriteJSON(&out, df); err != nil {
+		return nil, err
+	}
+	frame := jsonFrame{Dtypes: make(map[string]string), Columns: bytes.TrimSpace(out.Bytes())}
+	for name, col := range df.columns {
+		frame.Dtypes[name] = col.dType.String()
+	}
+	return json.Marshal(frame)
+}
+
+// UnmarshalJSON reads a frame written by MarshalJSON.
+func (df *DataFrame) UnmarshalJSON(data []byte) error {
+	var frame jsonFrame
+	if err := json.Unmarshal(data, &frame); err != nil {
+		err = ProcessingError{Err: errors.Wrap(err, "reading JSON")}
+		log.Get().Error(err.Error())
+		return err
+	}
+	reader := JSON{Orient: ColumnsOrient, Dtypes: make(map[string]element.Dtype), TimeLayout: time.RFC3339Nano}
+	for name, typeName := range frame.Dtypes {
+		dType, ok := dtypeNamed(typeName)
+		if !ok {
+			err := Unsupported{What: "type of column " + name, Value: typeName}
+			log.Get().Error(err.Error())
+			return err
+		}
+		reader.Dtypes[name] = dType
+	}
+	loaded, err := reader.LoadJSON(bytes.NewReader(frame.Columns))
+	if err != nil {
+		return err
+	}
+	*df = loaded
+	return nil
+}
+
+// dtypeNamed returns the type whose String is name.
+func dtypeNamed(name string) (element.Dtype, bool) {
+	for dType := element.IntType; dType <= element.TimeType; dType++ {
+		if dType.String() == name {
+			return dType, true
+		}
+	}
+	return 0, false
+}
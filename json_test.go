@@ -0,0 +1,160 @@
+package godata
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"github.com/tkhandel/go-data/element"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testRecordsJSON = `[
+	{"name": "apple", "qty": 3, "price": 1.5, "fresh": true, "tags": ["red"]},
+	{"name": "pear", "qty": null, "price": 2, "fresh": false, "tags": "green"},
+	{"name": "plum", "price": 0.25, "extra": "x"}
+]`
+
+func TestJSON_LoadJSON_Records(t *testing.T) {
+	df, err := JSON{}.LoadJSON(strings.NewReader(testRecordsJSON))
+	require.NoError(t, err)
+	require.ElementsMatch(t, []Column{
+		NewStringColumn("name"),
+		NewIntColumn("qty"),
+		NewFloatColumn("price"),
+		NewBoolColumn("fresh"),
+		NewStringColumn("tags"),
+		NewStringColumn("extra"),
+	}, df.Columns())
+
+	qty, err := df.IntColumn("qty")
+	require.NoError(t, err)
+	require.Equal(t, NewIntSeries(3).AppendNull().AppendNull(), qty)
+	price, err := df.FloatColumn("price")
+	require.NoError(t, err)
+	require.Equal(t, NewFloatSeries(1.5, 2, 0.25), price)
+	fresh, err := df.BoolColumn("fresh")
+	require.NoError(t, err)
+	require.Equal(t, NewBoolSeries(true, false).AppendNull(), fresh)
+	tags, err := df.StringColumn("tags")
+	require.NoError(t, err)
+	require.Equal(t, NewStringSeries(`["red"]`, "green").AppendNull(), tags)
+	extra, err := df.StringColumn("extra")
+	require.NoError(t, err)
+	require.Equal(t, TruthFilter{true, true, false}, extra.IsNull())
+}
+
+func TestJSON_LoadJSON_ColumnsAndLines(t *testing.T) {
+	j := JSON{Orient: ColumnsOrient, TimeLayout: "2006-01-02", Dtypes: map[string]element.Dtype{"qty": element.FloatType}}
+	df, err := j.LoadJSON(strings.NewReader(`{"day": ["2020-01-02", null], "qty": [1, 2]}`))
+	require.NoError(t, err)
+	day, err := df.TimeColumn("day")
+	require.NoError(t, err)
+	require.Equal(t, NewTimeSeries(time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)).AppendNull(), day)
+	qty, err := df.FloatColumn("qty")
+	require.NoError(t, err)
+	require.Equal(t, NewFloatSeries(1, 2), qty)
+
+	df, err = JSON{Orient: LinesOrient}.LoadJSON(strings.NewReader("{\"a\": 1}\n{\"a\": 2.5, \"b\": \"x\"}\n"))
+	require.NoError(t, err)
+	a, err := df.FloatColumn("a")
+	require.NoError(t, err)
+	require.Equal(t, NewFloatSeries(1, 2.5), a)
+	b, err := df.StringColumn("b")
+	require.NoError(t, err)
+	require.Equal(t, NewStringSeries().AppendNull().Concat(NewStringSeries("x")), b)
+
+	j = JSON{Dtypes: map[string]element.Dtype{"name": element.IntType}}
+	_, err = j.LoadJSON(strings.NewReader(testRecordsJSON))
+	require.IsType(t, ProcessingError{}, err)
+	require.Contains(t, err.Error(), "parsing row 0 of column name")
+
+	_, err = JSON{}.LoadJSON(strings.NewReader(`{"a": [1]}`))
+	require.IsType(t, ProcessingError{}, err)
+}
+
+func TestJSON_LoadJSON_DuplicateKeys(t *testing.T) {
+	_, err := JSON{}.LoadJSON(strings.NewReader(`[{"a": 1, "b": 2, "a": 2}, {"a": 3, "b": 4}]`))
+	require.IsType(t, ProcessingError{}, err)
+	require.Contains(t, err.Error(), "duplicate key in row 0: a")
+
+	_, err = JSON{Orient: LinesOrient}.LoadJSON(strings.NewReader("{\"a\": 1}\n{\"a\": 2, \"a\": 3}\n"))
+	require.Contains(t, err.Error(), "duplicate key in row 1: a")
+
+	_, err = JSON{Orient: ColumnsOrient}.LoadJSON(strings.NewReader(`{"a": [1], "a": [2]}`))
+	require.IsType(t, ProcessingError{}, err)
+	require.Contains(t, err.Error(), "duplicate key: a")
+}
+
+func TestJSON_WriteJSON(t *testing.T) {
+	df, err := CSV{HeadersPresent: true}.LoadCSV(strings.NewReader(testCSV))
+	require.NoError(t, err)
+	df, err = df.SetFloatColumn("price", NewFloatSeries(1.5, 2, math.NaN()))
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, JSON{}.WriteJSON(&out, df))
+	require.Equal(t, `[{"name":"apple","qty":3,"price":1.5},{"name":"pear","qty":4,"price":2.0},`+
+		`{"name":"plum","qty":null,"price":null}]`+"\n", out.String())
+
+	out.Reset()
+	require.NoError(t, JSON{}.WriteNDJSON(&out, df))
+	require.Equal(t, `{"name":"apple","qty":3,"price":1.5}`+"\n"+`{"name":"pear","qty":4,"price":2.0}`+"\n"+
+		`{"name":"plum","qty":null,"price":null}`+"\n", out.String())
+
+	out.Reset()
+	require.NoError(t, JSON{Orient: ColumnsOrient}.WriteJSON(&out, df))
+	require.Equal(t, `{"name":["apple","pear","plum"],"qty":[3,4,null],"price":[1.5,2.0,null]}`+"\n", out.String())
+
+	loaded, err := JSON{Orient: ColumnsOrient}.LoadJSON(&out)
+	require.NoError(t, err)
+	require.Equal(t, df.Columns(), loaded.Columns())
+}
+
+func TestDataFrame_MarshalJSON(t *testing.T) {
+	df := testDF()
+	data, err := json.Marshal(map[string]DataFrame{"frame": df})
+	require.NoError(t, err)
+
+	var decoded map[string]DataFrame
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, df, decoded["frame"])
+
+	empty, err := NewDataFrame(NewIntColumn("a"))
+	require.NoError(t, err)
+	data, err = json.Marshal(empty)
+	require.NoError(t, err)
+	require.Equal(t, `{"dtypes":{"a":"Integer"},"columns":{"a":[]}}`, string(data))
+	var decodedEmpty DataFrame
+	require.NoError(t, json.Unmarshal(data, &decodedEmpty))
+	require.Equal(t, empty.Columns(), decodedEmpty.Columns())
+
+	require.IsType(t, Unsupported{}, json.Unmarshal([]byte(`{"dtypes":{"a":"Decimal"},"columns":{"a":[]}}`),
+		&decodedEmpty))
+	require.IsType(t, ProcessingError{}, json.Unmarshal([]byte(`[]`), &decodedEmpty))
+}
+
+func TestDataFrame_MarshalJSON_Dtypes(t *testing.T) {
+	picked := time.Date(2024, 3, 1, 12, 30, 0, 500, time.UTC)
+	df, err := NewDataFrame(NewIntColumn("nulls"), NewStringColumn("name"), NewIntColumn("qty"),
+		NewFloatColumn("price"), NewBoolColumn("fresh"), NewTimeColumn("picked"))
+	require.NoError(t, err)
+	df, err = df.SetStringColumn("name", NewStringSeries("2024-03-01T12:30:00Z", "true").AppendNull())
+	require.NoError(t, err)
+	df, err = df.SetIntColumn("qty", NewIntSeries(3, -4).AppendNull())
+	require.NoError(t, err)
+	df, err = df.SetFloatColumn("price", NewFloatSeries(2, 0.1).AppendNull())
+	require.NoError(t, err)
+	df, err = df.SetBoolColumn("fresh", NewBoolSeries(true, false).AppendNull())
+	require.NoError(t, err)
+	df, err = df.SetTimeColumn("picked", NewTimeSeries(picked, picked.Add(time.Hour)).AppendNull())
+	require.NoError(t, err)
+
+	data, err := json.Marshal(df)
+	require.NoError(t, err)
+	var decoded DataFrame
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, df, decoded)
+}
@@ -4,58 +4,425 @@ import (
 	"encoding/csv"
 	"fmt"
 	"github.com/pkg/errors"
+	"github.com/tkhandel/go-data/element"
 	"github.com/tkhandel/go-data/log"
 	"io"
+	"strconv"
+	"time"
 )
 
 type CSV struct {
 	HeadersPresent bool
+	// SampleSize is the number of data rows inspected to infer the type of each column.
+	// Zero or a negative value inspects the whole first chunk when reading in chunks, or the first
+	// DefaultSampleSize rows otherwise.
+	SampleSize int
+	// Dtypes overrides the inferred type of the named columns.
+	Dtypes map[string]element.Dtype
+	// Strict makes loading fail on the first value that cannot be parsed as the type of its column.
+	// Otherwise such values are loaded as nulls.
+	Strict bool
+	// NAValues lists the fields that are loaded as nulls, whatever the type of their column. Nil means
+	// only empty fields.
+	NAValues []string
+	// Delimiter separates the fields of a row. The zero value means a comma.
+	Delimiter rune
+	// Comment marks lines that are skipped when it is their first character. The zero value disables comments.
+	Comment rune
+	// LazyQuotes allows quotes in unquoted fields and non-doubled quotes in quoted fields.
+	LazyQuotes bool
+	// TrimLeadingSpace ignores leading white space in a field, even before an opening quote.
+	TrimLeadingSpace bool
+	// FloatFormat and FloatPrecision are passed to strconv.FormatFloat when writing floats. The zero
+	// FloatFormat writes the shortest representation that reads back to the same value.
+	FloatFormat    byte
+	FloatPrecision int
+	// NAToken is written in place of nulls, empty strings and NaN floats.
+	NAToken string
+	// TimeLayout is the layout, as understood by time.Parse, used to read and write time columns. When set,
+	// columns whose values all parse with it are inferred as times. The empty layout means time.RFC3339Nano
+	// and leaves inference off.
+	TimeLayout string
+	// TimeLocation is the location of the times read with a layout that has no zone. Nil means UTC.
+	TimeLocation *time.Location
 }
 
+// DefaultSampleSize is the number of rows inspected to infer the column types when neither SampleSize nor a chunk
+// size is given.
+const DefaultSampleSize = 1000
+
 func (c CSV) LoadCSV(rdr io.Reader) (DataFrame, error) {
+	chunks := c.ChunkIterator(rdr, 0)
+	df, err := chunks.readChunk()
+	if err == io.EOF {
+		return NewDataFrame(chunks.columns...)
+	}
+	return df, err
+}
+
+// LoadCSVChunks reads the data in frames of at most chunkSize rows and passes each of them to handle in order.
+// Column types are inferred once, from the sample at the start of the data, so every chunk has the same columns.
+func (c CSV) LoadCSVChunks(rdr io.Reader, chunkSize int, handle func(DataFrame) error) error {
+	chunks := c.ChunkIterator(rdr, chunkSize)
+	for chunks.HasNext() {
+		if err := handle(chunks.Next()); err != nil {
+			return err
+		}
+	}
+	return chunks.Error()
+}
+
+// ChunkIterator returns an iterator over frames of at most chunkSize rows. A chunkSize of zero or less
+// reads all the remaining rows into a single frame.
+func (c CSV) ChunkIterator(rdr io.Reader, chunkSize int) *ChunkIterator {
 	csvRdr := csv.NewReader(rdr)
-	rows, err := csvRdr.ReadAll()
+	if c.Delimiter != 0 {
+		csvRdr.Comma = c.Delimiter
+	}
+	csvRdr.Comment = c.Comment
+	csvRdr.LazyQuotes = c.LazyQuotes
+	csvRdr.TrimLeadingSpace = c.TrimLeadingSpace
+	csvRdr.ReuseRecord = true
+
+	na := make(map[string]bool)
+	if c.NAValues == nil {
+		na[""] = true
+	}
+	for _, val := range c.NAValues {
+		na[val] = true
+	}
+	return &ChunkIterator{csv: c, rdr: csvRdr, chunkSize: chunkSize, na: na}
+}
+
+type ChunkIterator struct {
+	csv       CSV
+	rdr       *csv.Reader
+	chunkSize int
+	na        map[string]bool
+
+	started bool
+	columns []Column
+	sample  [][]string
+	row     int
+
+	chunk DataFrame
+	ready bool
+	err   error
+}
+
+func (i *ChunkIterator) HasNext() bool {
+	if i.ready {
+		return true
+	}
+	if i.err != nil {
+		return false
+	}
+
+	chunk, err := i.readChunk()
 	if err != nil {
-		readErr := ProcessingError{Err: errors.Wrap(err, "reading data rows")}
-		log.Get().Error(readErr.Error())
-		return DataFrame{}, readErr
+		if err != io.EOF {
+			i.err = err
+		}
+		return false
+	}
+	i.chunk, i.ready = chunk, true
+	return true
+}
+
+func (i *ChunkIterator) Next() DataFrame {
+	i.ready = false
+	return i.chunk
+}
+
+func (i *ChunkIterator) Error() error {
+	return i.err
+}
+
+// readChunk returns io.EOF once there are no rows left.
+func (i *ChunkIterator) readChunk() (DataFrame, error) {
+	if !i.started {
+		i.started = true
+		if err := i.readHeader(); err != nil {
+			return DataFrame{}, err
+		}
+	}
+	if len(i.columns) == 0 {
+		return DataFrame{}, io.EOF
 	}
 
-	if len(rows) == 0 {
-		return NewDataFrame()
+	builders := make([]*columnBuilder, len(i.columns))
+	for j, col := range i.columns {
+		builders[j] = i.csv.newColumnBuilder(col, i.chunkSize, i.na)
 	}
 
-	var columns []Column
-	if c.HeadersPresent {
-		for _, col := range rows[0] {
-			columns = append(columns, NewStringColumn(col))
+	rows := 0
+	for ; i.chunkSize <= 0 || rows < i.chunkSize; rows++ {
+		record, err := i.nextRecord()
+		if err == io.EOF {
+			break
 		}
-		rows = rows[1:]
-	} else {
-		for i := range rows[0] {
-			columns = append(columns, NewStringColumn(fmt.Sprintf("Column %d", i)))
+		if err != nil {
+			return DataFrame{}, err
+		}
+
+		for j, builder := range builders {
+			if err := builder.append(record[j], i.row); err != nil {
+				log.Get().Error(err.Error())
+				return DataFrame{}, err
+			}
 		}
+		i.row++
 	}
-	df, err := NewDataFrame(columns...)
+	if rows == 0 {
+		return DataFrame{}, io.EOF
+	}
+
+	df, err := NewDataFrame(i.columns...)
 	if err != nil {
 		readErr := ProcessingError{Err: errors.Wrap(err, "creating data frame")}
 		log.Get().Error(readErr.Error())
 		return DataFrame{}, readErr
 	}
+	for _, builder := range builders {
+		df, err = builder.setOn(df)
+		if err != nil {
+			log.Get().Error(err.Error())
+			return DataFrame{}, err
+		}
+	}
+	return df, nil
+}
 
-	for j := range columns {
-		var colVal []string
-		for i := range rows {
-			colVal = append(colVal, rows[i][j])
+// readHeader names the columns and infers their types from the sample rows, which are kept to be read again.
+func (i *ChunkIterator) readHeader() error {
+	sampleSize := i.csv.SampleSize
+	if sampleSize <= 0 {
+		sampleSize = i.chunkSize
+	}
+	if sampleSize <= 0 {
+		sampleSize = DefaultSampleSize
+	}
+
+	var names []string
+	for len(i.sample) < sampleSize {
+		record, err := i.rdr.Read()
+		if err == io.EOF {
+			break
 		}
-		df, err = df.SetStringColumn(columns[j].name, NewStringSeries(colVal...))
 		if err != nil {
-			setErr := ProcessingError{
-				Err: errors.Wrapf(err, "setting value of %s as string series", columns[j].name),
+			return i.readError(err)
+		}
+
+		if names == nil && i.csv.HeadersPresent {
+			names = append(names, record...)
+			continue
+		}
+		if names == nil {
+			for j := range record {
+				names = append(names, fmt.Sprintf("Column %d", j))
 			}
-			log.Get().Error(setErr.Error())
-			return DataFrame{}, setErr
+		}
+		i.sample = append(i.sample, append([]string(nil), record...))
+	}
+
+	for j, name := range names {
+		dType, ok := i.csv.Dtypes[name]
+		if !ok {
+			dType = i.csv.inferDtype(i.sample, j, i.na)
+		}
+		i.columns = append(i.columns, Column{name: name, dType: dType})
+	}
+	return nil
+}
+
+func (i *ChunkIterator) nextRecord() ([]string, error) {
+	if len(i.sample) > 0 {
+		// drop the rows as they are read so that the sample does not outlive them
+		record := i.sample[0]
+		i.sample[0] = nil
+		i.sample = i.sample[1:]
+		if len(i.sample) == 0 {
+			i.sample = nil
+		}
+		return record, nil
+	}
+
+	record, err := i.rdr.Read()
+	if err != nil && err != io.EOF {
+		return nil, i.readError(err)
+	}
+	return record, err
+}
+
+func (i *ChunkIterator) readError(err error) error {
+	readErr := ProcessingError{Err: errors.Wrap(err, "reading data rows")}
+	log.Get().Error(readErr.Error())
+	return readErr
+}
+
+// inferDtype picks the narrowest type that every value of column j in rows parses as, leaving out nulls.
+// Booleans are only tried once the values are not numbers, so that columns of 0 and 1 stay ints, and times only
+// when a TimeLayout is set.
+func (c CSV) inferDtype(rows [][]string, j int, na map[string]bool) element.Dtype {
+	isInt, isFloat, isBool, isTime, seen := true, true, true, c.TimeLayout != "", false
+	for _, row := range rows {
+		val := row[j]
+		if na[val] {
+			continue
+		}
+		seen = true
+		if isInt {
+			if _, err := strconv.ParseInt(val, 10, 64); err != nil {
+				isInt = false
+			}
+		}
+		if !isInt && isFloat {
+			if _, err := strconv.ParseFloat(val, 64); err != nil {
+				isFloat = false
+			}
+		}
+		if isBool {
+			if _, err := strconv.ParseBool(val); err != nil {
+				isBool = false
+			}
+		}
+		if isTime {
+			if _, err := c.parseTime(val); err != nil {
+				isTime = false
+			}
+		}
+		if !isInt && !isFloat && !isBool && !isTime {
+			break
+		}
+	}
+
+	switch {
+	case !seen:
+		return element.StringType
+	case isInt:
+		return element.IntType
+	case isFloat:
+		return element.FloatType
+	case isBool:
+		return element.BoolType
+	case isTime:
+		return element.TimeType
+	}
+	return element.StringType
+}
+
+func (c CSV) timeLayout() string {
+	if c.TimeLayout == "" {
+		return time.RFC3339Nano
+	}
+	return c.TimeLayout
+}
+
+func (c CSV) parseTime(val string) (time.Time, error) {
+	loc := c.TimeLocation
+	if loc == nil {
+		loc = time.UTC
+	}
+	return time.ParseInLocation(c.timeLayout(), val, loc)
+}
+
+// columnBuilder parses the values of one column straight into the slice backing its series.
+type columnBuilder struct {
+	col    Column
+	strict bool
+	na     map[string]bool
+	values valueBuilder
+}
+
+// valueBuilder accumulates the values of a column of one type.
+type valueBuilder interface {
+	// append parses val and adds it, or adds a null when parse is false
+	append(val string, parse bool) error
+	series() AnySeries
+}
+
+type seriesBuilder[T any] struct {
+	parse func(string) (T, error)
+	data  []T
+	valid bitmapBuilder
+}
+
+func newSeriesBuilder[T any](capacity int, parse func(string) (T, error)) *seriesBuilder[T] {
+	return &seriesBuilder[T]{parse: parse, data: make([]T, 0, capacity)}
+}
+
+func (b *seriesBuilder[T]) append(val string, parse bool) error {
+	var parsed T
+	if parse {
+		var err error
+		if parsed, err = b.parse(val); err != nil {
+			return err
+		}
+	}
+	b.data = append(b.data, parsed)
+	b.valid.append(parse)
+	return nil
+}
+
+func (b *seriesBuilder[T]) series() AnySeries {
+	return Series[T]{data: b.data, valid: b.valid.bitmap()}
+}
+
+func (c CSV) newColumnBuilder(col Column, capacity int, na map[string]bool) *columnBuilder {
+	if capacity < 0 {
+		capacity = 0
+	}
+
+	builder := &columnBuilder{col: col, strict: c.Strict, na: na}
+	switch col.dType {
+	case element.StringType:
+		builder.values = newSeriesBuilder(capacity, func(val string) (string, error) { return val, nil })
+	case element.IntType:
+		builder.values = newSeriesBuilder(capacity, func(val string) (int64, error) {
+			return strconv.ParseInt(val, 10, 64)
+		})
+	case element.FloatType:
+		builder.values = newSeriesBuilder(capacity, func(val string) (float64, error) {
+			return strconv.ParseFloat(val, 64)
+		})
+	case element.BoolType:
+		builder.values = newSeriesBuilder(capacity, strconv.ParseBool)
+	case element.TimeType:
+		builder.values = newSeriesBuilder(capacity, c.parseTime)
+	}
+	return builder
+}
+
+func (b *columnBuilder) append(val string, row int) error {
+	if b.values == nil {
+		return nil
+	}
+	valid := !b.na[val]
+	err := b.values.append(val, valid)
+	if err == nil {
+		return nil
+	}
+	if b.strict {
+		return b.parseError(err, row)
+	}
+	return b.values.append(val, false)
+}
+
+func (b *columnBuilder) setOn(df DataFrame) (DataFrame, error) {
+	if b.values == nil {
+		return df, nil
+	}
+	df, err := df.setSeries(b.col.name, b.values.series())
+	if err != nil {
+		return DataFrame{}, ProcessingError{
+			Err: errors.Wrapf(err, "setting value of %s as %s series", b.col.name, b.col.dType),
 		}
 	}
 	return df, nil
 }
+
+func (b *columnBuilder) parseError(err error, row int) error {
+	return ProcessingError{
+		Err: errors.Wrapf(err, "parsing row %d of column %s as %s", row, b.col.name, b.col.dType),
+	}
+}
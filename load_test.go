@@ -0,0 +1,179 @@
+package godata
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/require"
+	"github.com/tkhandel/go-data/element"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testCSV = `name,qty,price
+apple,3,1.5
+pear,4,2
+plum,,0.25
+`
+
+func TestCSV_LoadCSV_InferTypes(t *testing.T) {
+	df, err := CSV{HeadersPresent: true}.LoadCSV(strings.NewReader(testCSV))
+	require.NoError(t, err)
+	require.ElementsMatch(t, []Column{
+		NewStringColumn("name"),
+		NewIntColumn("qty"),
+		NewFloatColumn("price"),
+	}, df.Columns())
+
+	name, err := df.StringColumn("name")
+	require.NoError(t, err)
+	require.Equal(t, NewStringSeries("apple", "pear", "plum"), name)
+
+	qty, err := df.IntColumn("qty")
+	require.NoError(t, err)
+	require.Equal(t, NewIntSeries(3, 4).AppendNull(), qty)
+
+	price, err := df.FloatColumn("price")
+	require.NoError(t, err)
+	require.Equal(t, NewFloatSeries(1.5, 2, 0.25), price)
+}
+
+func TestCSV_LoadCSV_NoHeaders(t *testing.T) {
+	df, err := CSV{}.LoadCSV(strings.NewReader("1,a\n2,b\n"))
+	require.NoError(t, err)
+	require.ElementsMatch(t, []Column{
+		NewIntColumn("Column 0"),
+		NewStringColumn("Column 1"),
+	}, df.Columns())
+}
+
+func TestCSV_LoadCSV_DtypeOverride(t *testing.T) {
+	c := CSV{HeadersPresent: true, Dtypes: map[string]element.Dtype{
+		"qty":   element.FloatType,
+		"price": element.StringType,
+	}}
+	df, err := c.LoadCSV(strings.NewReader(testCSV))
+	require.NoError(t, err)
+
+	qty, err := df.FloatColumn("qty")
+	require.NoError(t, err)
+	require.Equal(t, NewFloatSeries(3, 4).AppendNull(), qty)
+
+	price, err := df.StringColumn("price")
+	require.NoError(t, err)
+	require.Equal(t, NewStringSeries("1.5", "2", "0.25"), price)
+}
+
+func TestCSV_LoadCSV_Strict(t *testing.T) {
+	c := CSV{HeadersPresent: true, Strict: true, SampleSize: 1}
+	_, err := c.LoadCSV(strings.NewReader("qty\n1\n2\nthree\n"))
+	require.Error(t, err)
+	require.IsType(t, ProcessingError{}, err)
+	require.Contains(t, err.Error(), "row 2 of column qty")
+
+	c.Strict = false
+	df, err := c.LoadCSV(strings.NewReader("qty\n1\n2\nthree\n"))
+	require.NoError(t, err)
+	qty, err := df.IntColumn("qty")
+	require.NoError(t, err)
+	require.Equal(t, NewIntSeries(1, 2).AppendNull(), qty)
+}
+
+func TestCSV_LoadCSV_DefaultSampleSize(t *testing.T) {
+	data := "qty\n" + strings.Repeat("1\n", DefaultSampleSize) + "1.5\n"
+	df, err := CSV{HeadersPresent: true}.LoadCSV(strings.NewReader(data))
+	require.NoError(t, err)
+	qty, err := df.IntColumn("qty")
+	require.NoError(t, err)
+	require.Equal(t, DefaultSampleSize+1, qty.Size())
+	require.False(t, qty.Valid(DefaultSampleSize))
+
+	df, err = CSV{HeadersPresent: true, SampleSize: DefaultSampleSize + 1}.LoadCSV(strings.NewReader(data))
+	require.NoError(t, err)
+	_, err = df.FloatColumn("qty")
+	require.NoError(t, err)
+}
+
+func TestCSV_LoadCSVChunks(t *testing.T) {
+	c := CSV{HeadersPresent: true, Delimiter: ';', Comment: '#'}
+	data := "# exported prices\nname;qty\napple;3\npear;4\n# skipped\nplum;5\n"
+
+	var sizes []int
+	var qty IntSeries
+	err := c.LoadCSVChunks(strings.NewReader(data), 2, func(chunk DataFrame) error {
+		val, err := chunk.IntColumn("qty")
+		require.NoError(t, err)
+		sizes = append(sizes, val.Size())
+		qty = qty.Concat(val)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []int{2, 1}, sizes)
+	require.Equal(t, NewIntSeries(3, 4, 5), qty)
+}
+
+func TestCSV_ChunkIterator_StrictAcrossChunks(t *testing.T) {
+	c := CSV{HeadersPresent: true, Strict: true}
+	chunks := c.ChunkIterator(strings.NewReader("qty\n1\n2\nthree\n"), 2)
+
+	require.True(t, chunks.HasNext())
+	require.True(t, chunks.HasNext())
+	first, err := chunks.Next().IntColumn("qty")
+	require.NoError(t, err)
+	require.Equal(t, NewIntSeries(1, 2), first)
+
+	require.False(t, chunks.HasNext())
+	require.IsType(t, ProcessingError{}, chunks.Error())
+	require.Contains(t, chunks.Error().Error(), "row 2 of column qty")
+}
+
+func TestCSV_LoadCSV_HeaderOnly(t *testing.T) {
+	df, err := CSV{HeadersPresent: true}.LoadCSV(strings.NewReader("a,b\n"))
+	require.NoError(t, err)
+	require.ElementsMatch(t, []Column{NewStringColumn("a"), NewStringColumn("b")}, df.Columns())
+}
+
+func TestCSV_LoadCSV_NAValues(t *testing.T) {
+	c := CSV{HeadersPresent: true, NAValues: []string{"NA", "-"}}
+	df, err := c.LoadCSV(strings.NewReader("name,qty\napple,NA\n-,4\n,5\n"))
+	require.NoError(t, err)
+
+	name, err := df.StringColumn("name")
+	require.NoError(t, err)
+	require.Equal(t, TruthFilter{false, true, false}, name.IsNull())
+
+	qty, err := df.IntColumn("qty")
+	require.NoError(t, err)
+	require.Equal(t, NewIntSeries().AppendNull().Concat(NewIntSeries(4, 5)), qty)
+}
+
+func TestCSV_LoadCSV_BoolAndTime(t *testing.T) {
+	data := "day,open,qty\n2020-01-02,true,1\n2020-01-03,F,0\n,,1\n"
+	c := CSV{HeadersPresent: true, TimeLayout: "2006-01-02"}
+	df, err := c.LoadCSV(strings.NewReader(data))
+	require.NoError(t, err)
+	require.ElementsMatch(t, []Column{
+		NewTimeColumn("day"),
+		NewBoolColumn("open"),
+		NewIntColumn("qty"),
+	}, df.Columns())
+
+	day, err := df.TimeColumn("day")
+	require.NoError(t, err)
+	require.Equal(t, NewTimeSeries(
+		time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 1, 3, 0, 0, 0, 0, time.UTC),
+	).AppendNull(), day)
+
+	open, err := df.BoolColumn("open")
+	require.NoError(t, err)
+	require.Equal(t, NewBoolSeries(true, false).AppendNull(), open)
+
+	var out bytes.Buffer
+	require.NoError(t, c.WriteCSV(&out, df, "day", "open"))
+	require.Equal(t, "day,open\n2020-01-02,true\n2020-01-03,false\n,\n", out.String())
+
+	df, err = CSV{HeadersPresent: true}.LoadCSV(strings.NewReader(data))
+	require.NoError(t, err)
+	_, err = df.StringColumn("day")
+	require.NoError(t, err)
+}
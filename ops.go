@@ -0,0 +1,132 @@
+package godata
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// Number is the constraint for the values that can be added up.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// Sum adds up the values, skipping the nulls.
+func Sum[T Number](s Series[T]) T {
+	var sum T
+	for x, entry := range s.data {
+		if s.Valid(x) {
+			sum += entry
+		}
+	}
+	return sum
+}
+
+// Avg returns the mean of the values, skipping the nulls.
+func Avg[T Number](s Series[T]) float64 {
+	return float64(Sum(s)) / float64(s.Size()-s.nullCount())
+}
+
+// Max skips the nulls. The position is -1 when there is no value.
+func Max[T cmp.Ordered](s Series[T]) (pos int, max T) {
+	pos = -1
+	for x, entry := range s.data {
+		if s.Valid(x) && (pos < 0 || max < entry) {
+			max = entry
+			pos = x
+		}
+	}
+	return pos, max
+}
+
+// Min skips the nulls. The position is -1 when there is no value.
+func Min[T cmp.Ordered](s Series[T]) (pos int, min T) {
+	pos = -1
+	for x, entry := range s.data {
+		if s.Valid(x) && (pos < 0 || min > entry) {
+			min = entry
+			pos = x
+		}
+	}
+	return pos, min
+}
+
+// Sort orders the values in ascending order, with the nulls at the end.
+func Sort[T cmp.Ordered](s Series[T]) Series[T] {
+	return s.take(Argsort(s))
+}
+
+// Argsort returns the positions of the values in ascending order, with the nulls at the end. Equal values
+// keep their relative order.
+func Argsort[T cmp.Ordered](s Series[T]) []int {
+	positions := make([]int, s.Size())
+	for x := range positions {
+		positions[x] = x
+	}
+	slices.SortStableFunc(positions, func(x, y int) int {
+		if !s.Valid(x) || !s.Valid(y) {
+			return compareNulls(s.Valid(x), s.Valid(y))
+		}
+		return cmp.Compare(s.data[x], s.data[y])
+	})
+	return positions
+}
+
+func GreaterThan[T cmp.Ordered](s Series[T], value T) TruthFilter {
+	return s.Filter(func(entry T) bool { return entry > value })
+}
+
+func SmallerThan[T cmp.Ordered](s Series[T], value T) TruthFilter {
+	return s.Filter(func(entry T) bool { return entry < value })
+}
+
+// Find returns the position of the first value equal to val, or -1 when there is none.
+func Find[T comparable](s Series[T], val T) int {
+	for index, entry := range s.data {
+		if entry == val && s.Valid(index) {
+			return index
+		}
+	}
+	return -1
+}
+
+func Equal[T comparable](s Series[T], value T) TruthFilter {
+	return s.Filter(func(entry T) bool { return entry == value })
+}
+
+func NotEqual[T comparable](s Series[T], value T) TruthFilter {
+	return s.Filter(func(entry T) bool { return entry != value })
+}
+
+// IsTrue returns a filter that is true where the values are true, and false for the nulls.
+func IsTrue(s BoolSeries) TruthFilter {
+	return s.Filter(func(entry bool) bool { return entry })
+}
+
+// compareBools orders false before true.
+func compareBools(x, y bool) int {
+	switch {
+	case x == y:
+		return 0
+	case y:
+		return -1
+	}
+	return 1
+}
+
+// Before returns a filter that is true where the values are earlier than t.
+func Before(s TimeSeries, t time.Time) TruthFilter {
+	return s.Filter(func(entry time.Time) bool { return entry.Before(t) })
+}
+
+// After returns a filter that is true where the values are later than t.
+func After(s TimeSeries, t time.Time) TruthFilter {
+	return s.Filter(func(entry time.Time) bool { return entry.After(t) })
+}
+
+// EqualTime returns a filter that is true where the values are the same instant as t, whatever their location.
+func EqualTime(s TimeSeries, t time.Time) TruthFilter {
+	return s.Filter(func(entry time.Time) bool { return entry.Equal(t) })
+}
@@ -0,0 +1,125 @@
+package godata
+
+import (
+	"context"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/compress"
+	"github.com/apache/arrow-go/v18/parquet/file"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	"github.com/tkhandel/go-data/element"
+	"github.com/tkhandel/go-data/log"
+	"io"
+	"strconv"
+)
+
+// Compression is the codec compressing the pages of a Parquet file.
+type Compression int
+
+const (
+	Uncompressed Compression = iota
+	Snappy
+	Gzip
+	Zstd
+)
+
+var parquetCodecs = map[Compression]compress.Compression{
+	Uncompressed: compress.Codecs.Uncompressed,
+	Snappy:       compress.Codecs.Snappy,
+	Gzip:         compress.Codecs.Gzip,
+	Zstd:         compress.Codecs.Zstd,
+}
+
+type Parquet struct {
+	// Columns lists the columns to read, in the order of the file. Nil reads every column.
+	Columns []string
+	// RowGroups lists the positions of the row groups to read. Nil reads every row group.
+	RowGroups []int
+	// Compression compresses the written pages.
+	Compression Compression
+	// Dictionary writes string columns with dictionary encoding, which suits columns of few distinct values.
+	Dictionary bool
+	// RowGroupSize is the largest number of rows written per row group. Zero or a negative value writes the
+	// default of the Parquet library.
+	RowGroupSize int
+}
+
+// LoadParquet reads the Parquet file of size bytes held by rdr. The types of the columns follow the Arrow types
+// the file maps to: integers become ints, floating point numbers floats, strings and binaries strings,
+// booleans bools, and timestamps and dates times.
+func (p Parquet) LoadParquet(rdr io.ReaderAt, size int64) (DataFrame, error) {
+	pf, err := file.NewParquetReader(io.NewSectionReader(rdr, 0, size))
+	if err != nil {
+		return DataFrame{}, arrowError(err, "reading Parquet file")
+	}
+	defer pf.Close()
+
+	var columns []int
+	for _, name := range p.Columns {
+		j := pf.MetaData().Schema.ColumnIndexByName(name)
+		if j < 0 {
+			err := Unknown{What: "column", Value: name}
+			log.Get().Error(err.Error())
+			return DataFrame{}, err
+		}
+		columns = append(columns, j)
+	}
+	for _, group := range p.RowGroups {
+		if group < 0 || group >= pf.NumRowGroups() {
+			err := Unknown{What: "row group", Value: strconv.Itoa(group)}
+			log.Get().Error(err.Error())
+			return DataFrame{}, err
+		}
+	}
+
+	pqRdr, err := pqarrow.NewFileReader(pf, pqarrow.ArrowReadProperties{BatchSize: 64 * 1024}, memory.DefaultAllocator)
+	if err != nil {
+		return DataFrame{}, arrowError(err, "reading Parquet file")
+	}
+	records, err := pqRdr.GetRecordReader(context.Background(), columns, p.RowGroups)
+	if err != nil {
+		return DataFrame{}, arrowError(err, "reading Parquet file")
+	}
+	defer records.Release()
+	return loadArrowRecords(records, "reading Parquet file")
+}
+
+// WriteParquet writes every column of the frame, in its order, as a Parquet file. wrt is not closed.
+func (p Parquet) WriteParquet(wrt io.Writer, df DataFrame) error {
+	codec, ok := parquetCodecs[p.Compression]
+	if !ok {
+		err := Unsupported{What: "compression", Value: strconv.Itoa(int(p.Compression))}
+		log.Get().Error(err.Error())
+		return err
+	}
+	options := []parquet.WriterProperty{parquet.WithCompression(codec), parquet.WithDictionaryDefault(false)}
+	if p.RowGroupSize > 0 {
+		options = append(options, parquet.WithMaxRowGroupLength(int64(p.RowGroupSize)))
+	}
+	for name, col := range df.columns {
+		if p.Dictionary && col.dType == element.StringType {
+			options = append(options, parquet.WithDictionaryFor(name, true))
+		}
+	}
+
+	rec, err := df.ToArrow()
+	if err != nil {
+		return err
+	}
+	defer rec.Release()
+
+	// the Parquet writer closes writers that can be closed
+	pqWrt, err := pqarrow.NewFileWriter(rec.Schema(), struct{ io.Writer }{wrt},
+		parquet.NewWriterProperties(options...), pqarrow.DefaultWriterProps())
+	if err != nil {
+		return arrowError(err, "writing Parquet file")
+	}
+	if err := pqWrt.Write(rec); err != nil {
+		pqWrt.Close()
+		return arrowError(err, "writing Parquet file")
+	}
+	if err := pqWrt.Close(); err != nil {
+		return arrowError(err, "writing Parquet file")
+	}
+	return nil
+}
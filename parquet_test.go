@@ -0,0 +1,53 @@
+package godata
+
+import (
+	"bytes"
+	"github.com/apache/arrow-go/v18/parquet/file"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParquet_RoundTrip(t *testing.T) {
+	df := testArrowDF(t)
+	for _, compression := range []Compression{Uncompressed, Snappy, Gzip, Zstd} {
+		var out bytes.Buffer
+		require.NoError(t, Parquet{Compression: compression}.WriteParquet(&out, df))
+
+		loaded, err := Parquet{}.LoadParquet(bytes.NewReader(out.Bytes()), int64(out.Len()))
+		require.NoError(t, err)
+		require.Equal(t, df, loaded)
+	}
+
+	require.IsType(t, Unsupported{}, Parquet{Compression: 42}.WriteParquet(&bytes.Buffer{}, df))
+	_, err := Parquet{}.LoadParquet(bytes.NewReader([]byte("not parquet")), 11)
+	require.IsType(t, ProcessingError{}, err)
+}
+
+func TestParquet_ProjectionAndRowGroups(t *testing.T) {
+	df := testArrowDF(t)
+	var out bytes.Buffer
+	require.NoError(t, Parquet{Dictionary: true, RowGroupSize: 2}.WriteParquet(&out, df))
+	data := bytes.NewReader(out.Bytes())
+
+	pf, err := file.NewParquetReader(data)
+	require.NoError(t, err)
+	require.Equal(t, 2, pf.NumRowGroups())
+	name, err := pf.MetaData().RowGroup(0).ColumnChunk(pf.MetaData().Schema.ColumnIndexByName("name"))
+	require.NoError(t, err)
+	require.True(t, name.HasDictionaryPage())
+	qty, err := pf.MetaData().RowGroup(0).ColumnChunk(pf.MetaData().Schema.ColumnIndexByName("qty"))
+	require.NoError(t, err)
+	require.False(t, qty.HasDictionaryPage())
+
+	loaded, err := Parquet{Columns: []string{"qty", "name"}, RowGroups: []int{1}}.LoadParquet(data, data.Size())
+	require.NoError(t, err)
+	require.ElementsMatch(t, []Column{NewStringColumn("name"), NewIntColumn("qty")}, loaded.Columns())
+	names, err := loaded.StringColumn("name")
+	require.NoError(t, err)
+	require.Equal(t, NewStringSeries().AppendNull(), names)
+
+	_, err = Parquet{Columns: []string{"foo"}}.LoadParquet(data, data.Size())
+	require.IsType(t, Unknown{}, err)
+	_, err = Parquet{RowGroups: []int{2}}.LoadParquet(data, data.Size())
+	require.IsType(t, Unknown{}, err)
+}
@@ -0,0 +1,108 @@
+package godata
+
+import (
+	"github.com/pkg/errors"
+	"github.com/tkhandel/go-data/element"
+	"github.com/tkhandel/go-data/log"
+	"time"
+)
+
+// Frequency is the width of the buckets rows are resampled into.
+type Frequency string
+
+const (
+	Minutely Frequency = "minute"
+	Hourly   Frequency = "hour"
+	Daily    Frequency = "day"
+	// Weekly buckets start on Mondays.
+	Weekly  Frequency = "week"
+	Monthly Frequency = "month"
+)
+
+// truncate returns the start of the bucket holding t, in the location of t.
+func (f Frequency) truncate(t time.Time) (time.Time, bool) {
+	year, month, day := t.Date()
+	switch f {
+	case Minutely:
+		return time.Date(year, month, day, t.Hour(), t.Minute(), 0, 0, t.Location()), true
+	case Hourly:
+		return time.Date(year, month, day, t.Hour(), 0, 0, 0, t.Location()), true
+	case Daily:
+		return time.Date(year, month, day, 0, 0, 0, 0, t.Location()), true
+	case Weekly:
+		return time.Date(year, month, day-(int(t.Weekday())+6)%7, 0, 0, 0, 0, t.Location()), true
+	case Monthly:
+		return time.Date(year, month, 1, 0, 0, 0, 0, t.Location()), true
+	}
+	return time.Time{}, false
+}
+
+// Resample groups the rows of the frame on the bucket of freq their timestamp falls in. The timestamps are read
+// from column tsCol, which holds times, RFC 3339 strings or epoch seconds. In the grouped frame, tsCol holds the
+// start of the bucket and the groups are in time order; buckets without rows are left out and rows with a null
+// timestamp are skipped. The groups are then aggregated with Agg, or AggNumeric for every numeric column, e.g.
+//
+//	resampled, err := df.Resample("created", Hourly)
+//	hourly, err := resampled.AggNumeric(AggMean)
+func (df DataFrame) Resample(tsCol string, freq Frequency) (GroupedFrame, error) {
+	if _, ok := freq.truncate(time.Time{}); !ok {
+		err := Unsupported{What: "resample frequency", Value: string(freq)}
+		log.Get().Error(err.Error())
+		return GroupedFrame{}, err
+	}
+	times, err := df.timestamps(tsCol)
+	if err != nil {
+		log.Get().Error(err.Error())
+		return GroupedFrame{}, err
+	}
+
+	buckets := times.Apply(func(t time.Time) time.Time {
+		bucket, _ := freq.truncate(t)
+		return bucket
+	})
+	resampled, err := df.replaceSeries(tsCol, buckets)
+	if err != nil {
+		return GroupedFrame{}, err
+	}
+	if resampled, err = resampled.SortBy(SortKey{Column: tsCol}); err != nil {
+		return GroupedFrame{}, err
+	}
+	return resampled.GroupBy(tsCol)
+}
+
+// timestamps reads column name as times. Epoch seconds are read in UTC.
+func (df DataFrame) timestamps(name string) (TimeSeries, error) {
+	col, ok := df.columns[name]
+	if !ok {
+		return TimeSeries{}, Unknown{What: "column", Value: name}
+	}
+
+	switch series := df.series[name].(type) {
+	case TimeSeries:
+		return series, nil
+	case IntSeries:
+		times := TimeSeries{data: make([]time.Time, series.Size()), valid: series.valid}
+		for x, val := range series.data {
+			if series.Valid(x) {
+				times.data[x] = time.Unix(val, 0).UTC()
+			}
+		}
+		return times, nil
+	case StringSeries:
+		times := TimeSeries{data: make([]time.Time, series.Size()), valid: series.valid}
+		for x, val := range series.data {
+			if !series.Valid(x) {
+				continue
+			}
+			parsed, err := time.Parse(time.RFC3339, val)
+			if err != nil {
+				return TimeSeries{}, ProcessingError{
+					Err: errors.Wrapf(err, "parsing row %d of column %s as %s", x, name, element.TimeType),
+				}
+			}
+			times.data[x] = parsed
+		}
+		return times, nil
+	}
+	return TimeSeries{}, Unsupported{What: "timestamp column type", Value: col.dType.String()}
+}
@@ -0,0 +1,62 @@
+package godata
+
+import (
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testMetricsCSV = `at,host,load,requests
+2020-03-02T10:15:00Z,a,0.5,10
+2020-03-02T10:45:00Z,b,1.5,30
+2020-03-02T09:59:59Z,a,1,5
+2020-03-03T00:10:00+01:00,a,2,
+`
+
+func TestDataFrame_Resample(t *testing.T) {
+	df, err := CSV{HeadersPresent: true}.LoadCSV(strings.NewReader(testMetricsCSV))
+	require.NoError(t, err)
+
+	resampled, err := df.Resample("at", Hourly)
+	require.NoError(t, err)
+	out, err := resampled.AggNumeric(AggMean)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []Column{NewTimeColumn("at"), NewFloatColumn("load"), NewFloatColumn("requests")}, out.Columns())
+
+	at, err := out.TimeColumn("at")
+	require.NoError(t, err)
+	require.Equal(t, []time.Time{
+		time.Date(2020, 3, 2, 9, 0, 0, 0, time.UTC),
+		time.Date(2020, 3, 2, 10, 0, 0, 0, time.UTC),
+		time.Date(2020, 3, 2, 23, 0, 0, 0, time.UTC),
+	}, []time.Time{at.Index(0), at.Index(1), at.Index(2).UTC()})
+	load, err := out.FloatColumn("load")
+	require.NoError(t, err)
+	require.Equal(t, NewFloatSeries(1, 1, 2), load)
+	requests, err := out.FloatColumn("requests")
+	require.NoError(t, err)
+	require.Equal(t, NewFloatSeries(5, 20).AppendNull(), requests)
+
+	df, err = NewDataFrame(NewIntColumn("epoch"), NewIntColumn("qty"))
+	require.NoError(t, err)
+	df, _ = df.SetIntColumn("epoch", NewIntSeries(1583020800, 1583107200, 1585699200))
+	df, _ = df.SetIntColumn("qty", NewIntSeries(1, 2, 3))
+	for freq, expected := range map[Frequency]IntSeries{
+		Weekly:  NewIntSeries(1, 2, 3),
+		Monthly: NewIntSeries(3, 3),
+	} {
+		resampled, err := df.Resample("epoch", freq)
+		require.NoError(t, err)
+		out, err := resampled.Agg(AggSum("qty"))
+		require.NoError(t, err)
+		qty, err := out.IntColumn("qty_sum")
+		require.NoError(t, err)
+		require.Equal(t, expected, qty, freq)
+	}
+
+	_, err = df.Resample("epoch", "fortnight")
+	require.IsType(t, Unsupported{}, err)
+	_, err = df.Resample("foo", Daily)
+	require.IsType(t, Unknown{}, err)
+}
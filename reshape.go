@@ -0,0 +1,180 @@
+package godata
+
+import (
+	"github.com/tkhandel/go-data/element"
+	"github.com/tkhandel/go-data/log"
+)
+
+// Pivot turns a long frame into a wide one, with a row per distinct value of index and a column per distinct value
+// of columns, both in the order they first appear, e.g.
+//
+//	df.Pivot("region", "month", "sales", AggSum)
+//
+// Every cell holds agg applied to the values of the rows with that pair of keys, and a null when there is no such
+// row. The new columns are named after the values of columns as formatted by Format. Rows with a null index or
+// columns value are left out, and neither can be a float column.
+func (df DataFrame) Pivot(index, columns, values string, agg func(column string) Aggregation) (DataFrame, error) {
+	grouped, err := df.GroupBy(index, columns)
+	if err != nil {
+		return DataFrame{}, err
+	}
+	// the aggregated values need a name of their own, as values may also be a key
+	cell := values
+	for cell == index || cell == columns {
+		cell += "_"
+	}
+	long, err := grouped.Agg(agg(values).As(cell))
+	if err != nil {
+		return DataFrame{}, err
+	}
+	if long, err = long.Cast(columns, element.StringType); err != nil {
+		return DataFrame{}, err
+	}
+	keys, err := long.StringColumn(columns)
+	if err != nil {
+		return DataFrame{}, err
+	}
+
+	byIndex, err := long.GroupBy(index)
+	if err != nil {
+		return DataFrame{}, err
+	}
+	rowOf := make([]int, long.NRows())
+	firsts := make([]int, byIndex.NGroups())
+	for k, rows := range byIndex.groups {
+		firsts[k] = rows[0]
+		for _, i := range rows {
+			rowOf[i] = k
+		}
+	}
+
+	outColumns := []Column{long.columns[index]}
+	cells := make(map[string][]interface{})
+	var names []string
+	for i := 0; i < long.NRows(); i++ {
+		name := keys.Index(i)
+		if _, ok := cells[name]; !ok {
+			cells[name] = make([]interface{}, len(firsts))
+			names = append(names, name)
+			outColumns = append(outColumns, Column{name: name, dType: long.columns[cell].dType})
+		}
+		cells[name][rowOf[i]] = long.value(cell, i)
+	}
+
+	out, err := NewDataFrame(outColumns...)
+	if err != nil {
+		return DataFrame{}, err
+	}
+	if out, err = out.setValues(index, long.columns[index].dType, long.takeValues(index, firsts)); err != nil {
+		return DataFrame{}, err
+	}
+	for _, name := range names {
+		if out, err = out.setValues(name, long.columns[cell].dType, cells[name]); err != nil {
+			return DataFrame{}, err
+		}
+	}
+	return out, nil
+}
+
+// Melt turns a wide frame into a long one, undoing Pivot. Every row gives a row per value column, holding the id
+// columns, the name of the value column as "variable" and its value as "value". The rows come value column by
+// value column. Without any value column, every column that is not an id is melted. The value columns must hold
+// the same type, except that ints and floats are melted together as floats.
+func (df DataFrame) Melt(idVars []string, valueVars []string) (DataFrame, error) {
+	for _, name := range append(append([]string(nil), idVars...), valueVars...) {
+		if _, ok := df.columns[name]; !ok {
+			err := Unknown{What: "column", Value: name}
+			log.Get().Error(err.Error())
+			return DataFrame{}, err
+		}
+	}
+	isID := make(map[string]bool)
+	for _, name := range idVars {
+		isID[name] = true
+	}
+	if len(valueVars) == 0 {
+		for _, name := range df.names {
+			if !isID[name] {
+				valueVars = append(valueVars, name)
+			}
+		}
+	}
+
+	dType, err := meltType(df, valueVars)
+	if err != nil {
+		log.Get().Error(err.Error())
+		return DataFrame{}, err
+	}
+	var columns []Column
+	for _, name := range idVars {
+		columns = append(columns, df.columns[name])
+	}
+	columns = append(columns, NewStringColumn("variable"), Column{name: "value", dType: dType})
+	out, err := NewDataFrame(columns...)
+	if err != nil {
+		return DataFrame{}, err
+	}
+
+	var rows []int
+	var variables []string
+	melted := newSeries(dType)
+	for _, name := range valueVars {
+		series := df.series[name]
+		if series.Dtype() != dType {
+			if series, err = castSeries(series, dType); err != nil {
+				log.Get().Error(err.Error())
+				return DataFrame{}, err
+			}
+		}
+		melted = melted.concatAny(series)
+		for i := 0; i < df.NRows(); i++ {
+			rows = append(rows, i)
+			variables = append(variables, name)
+		}
+	}
+
+	for _, name := range idVars {
+		if out, err = out.setSeries(name, df.series[name].takeAny(rows)); err != nil {
+			return DataFrame{}, err
+		}
+	}
+	if out, err = out.setSeries("variable", NewStringSeries(variables...)); err != nil {
+		return DataFrame{}, err
+	}
+	return out.setSeries("value", melted)
+}
+
+// meltType returns the type of the melted values of the given columns.
+func meltType(df DataFrame, columns []string) (element.Dtype, error) {
+	if len(columns) == 0 {
+		return element.StringType, nil
+	}
+	dType := df.columns[columns[0]].dType
+	for _, name := range columns[1:] {
+		other := df.columns[name].dType
+		switch {
+		case other == dType:
+		case (other == element.IntType || other == element.FloatType) &&
+			(dType == element.IntType || dType == element.FloatType):
+			dType = element.FloatType
+		default:
+			return 0, Unsupported{What: "melt of column " + name + " with " + dType.String() + " columns",
+				Value: other.String()}
+		}
+	}
+	return dType, nil
+}
+
+// Crosstab counts the rows of every pair of values of index and columns, laid out as by Pivot, with a count of
+// zero for the pairs that never appear.
+func (df DataFrame) Crosstab(index, columns string) (DataFrame, error) {
+	counts, err := df.Pivot(index, columns, index, AggCount)
+	if err != nil {
+		return DataFrame{}, err
+	}
+	zeros := make(map[string]interface{})
+	for _, name := range counts.names[1:] {
+		zeros[name] = int64(0)
+	}
+	return counts.FillNA(zeros)
+}
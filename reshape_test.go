@@ -0,0 +1,99 @@
+package godata
+
+import (
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+const testMonthlyCSV = `region,month,sales
+EU,1,10
+US,1,20
+EU,2,5
+EU,1,2.5
+US,3,
+APAC,2,7
+`
+
+func TestDataFrame_Pivot(t *testing.T) {
+	df, err := CSV{HeadersPresent: true}.LoadCSV(strings.NewReader(testMonthlyCSV))
+	require.NoError(t, err)
+
+	wide, err := df.Pivot("region", "month", "sales", AggSum)
+	require.NoError(t, err)
+	require.Equal(t, []Column{NewStringColumn("region"), NewFloatColumn("1"), NewFloatColumn("2"),
+		NewFloatColumn("3")}, wide.Columns())
+	regions, err := wide.StringColumn("region")
+	require.NoError(t, err)
+	require.Equal(t, NewStringSeries("EU", "US", "APAC"), regions)
+	jan, err := wide.FloatColumn("1")
+	require.NoError(t, err)
+	require.Equal(t, NewFloatSeries(12.5, 20).AppendNull(), jan)
+	mar, err := wide.FloatColumn("3")
+	require.NoError(t, err)
+	require.Equal(t, NewFloatSeries().AppendNull().Append(0).AppendNull(), mar)
+
+	wide, err = df.Pivot("region", "month", "sales", AggMean)
+	require.NoError(t, err)
+	feb, err := wide.FloatColumn("2")
+	require.NoError(t, err)
+	require.Equal(t, NewFloatSeries(5).AppendNull().Append(7), feb)
+
+	_, err = df.Pivot("region", "sales", "month", AggSum)
+	require.IsType(t, Unsupported{}, err)
+	_, err = df.Pivot("region", "month", "region", AggSum)
+	require.IsType(t, Unsupported{}, err)
+	_, err = df.Pivot("region", "foo", "sales", AggSum)
+	require.IsType(t, Unknown{}, err)
+}
+
+func TestDataFrame_Melt(t *testing.T) {
+	df, err := CSV{HeadersPresent: true}.LoadCSV(strings.NewReader(testMonthlyCSV))
+	require.NoError(t, err)
+	wide, err := df.Pivot("region", "month", "sales", AggMax)
+	require.NoError(t, err)
+
+	long, err := wide.Melt([]string{"region"}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []Column{NewStringColumn("region"), NewStringColumn("variable"), NewFloatColumn("value")},
+		long.Columns())
+	require.Equal(t, 9, long.NRows())
+	regions, err := long.StringColumn("region")
+	require.NoError(t, err)
+	require.Equal(t, NewStringSeries("EU", "US", "APAC", "EU", "US", "APAC", "EU", "US", "APAC"), regions)
+	variables, err := long.StringColumn("variable")
+	require.NoError(t, err)
+	require.Equal(t, NewStringSeries("1", "1", "1", "2", "2", "2", "3", "3", "3"), variables)
+	values, err := long.FloatColumn("value")
+	require.NoError(t, err)
+	require.Equal(t, NewFloatSeries(10, 20).AppendNull().Append(5).AppendNull().Append(7).AppendNull().AppendNull().
+		AppendNull(), values)
+
+	mixed := testDF()
+	long, err = mixed.Melt([]string{col1}, []string{col3, col4})
+	require.NoError(t, err)
+	values, err = long.FloatColumn("value")
+	require.NoError(t, err)
+	require.Equal(t, NewFloatSeries(5, 6, 7, 8, 9, 10), values)
+
+	_, err = mixed.Melt([]string{col1}, []string{col2, col3})
+	require.IsType(t, Unsupported{}, err)
+	_, err = mixed.Melt([]string{"foo"}, nil)
+	require.IsType(t, Unknown{}, err)
+}
+
+func TestDataFrame_Crosstab(t *testing.T) {
+	df, err := CSV{HeadersPresent: true}.LoadCSV(strings.NewReader("shop,fruit\nA,apple\nB,pear\nA,apple\nA,\nB,plum\n"))
+	require.NoError(t, err)
+
+	counts, err := df.Crosstab("shop", "fruit")
+	require.NoError(t, err)
+	require.Equal(t, []Column{NewStringColumn("shop"), NewIntColumn("apple"), NewIntColumn("pear"),
+		NewIntColumn("plum")}, counts.Columns())
+	apples, err := counts.IntColumn("apple")
+	require.NoError(t, err)
+	require.Equal(t, NewIntSeries(2, 0), apples)
+	plums, err := counts.IntColumn("plum")
+	require.NoError(t, err)
+	require.Equal(t, NewIntSeries(0, 1), plums)
+}
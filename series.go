@@ -0,0 +1,311 @@
+package godata
+
+import (
+	"cmp"
+	"github.com/tkhandel/go-data/element"
+	"time"
+)
+
+// Series holds the values of a column along with which of them are null. The operations that only need to move
+// values around are methods; those that need to add, order or compare values are functions constrained on T,
+// such as Sum, Sort or Find.
+type Series[T any] struct {
+	data  []T
+	valid bitmap
+}
+
+type (
+	IntSeries    = Series[int64]
+	FloatSeries  = Series[float64]
+	StringSeries = Series[string]
+	BoolSeries   = Series[bool]
+	TimeSeries   = Series[time.Time]
+)
+
+func NewSeries[T any](data ...T) Series[T] {
+	return Series[T]{data: data}
+}
+
+func NewIntSeries(data ...int64) IntSeries {
+	return NewSeries(data...)
+}
+
+func NewFloatSeries(data ...float64) FloatSeries {
+	return NewSeries(data...)
+}
+
+func NewStringSeries(data ...string) StringSeries {
+	return NewSeries(data...)
+}
+
+func NewBoolSeries(data ...bool) BoolSeries {
+	return NewSeries(data...)
+}
+
+func NewTimeSeries(data ...time.Time) TimeSeries {
+	return NewSeries(data...)
+}
+
+// AnySeries is the view of a series that does not depend on the type of its values. It lets a frame hold
+// series of every type side by side.
+type AnySeries interface {
+	Size() int
+	Valid(index int) bool
+	IsNull() TruthFilter
+	NotNull() TruthFilter
+	Dtype() element.Dtype
+	Element(index int) element.Element
+
+	// value returns the value at index, or nil when it is null
+	value(index int) interface{}
+	takeAny(positions []int) AnySeries
+	concatAny(x AnySeries) AnySeries
+	// fillAny replaces the nulls with value, reporting false when value is not of the type of the series
+	fillAny(value interface{}) (AnySeries, bool)
+	// withValues returns a series of the same type holding values, where nil stands for a null
+	withValues(values []interface{}) AnySeries
+	// compare orders the values at x and y ascending, with the nulls last
+	compare(x, y int) int
+}
+
+// Dtype returns the type of the values, or the zero Dtype when a frame cannot hold them.
+func (s Series[T]) Dtype() element.Dtype {
+	switch any(s.data).(type) {
+	case []int64:
+		return element.IntType
+	case []float64:
+		return element.FloatType
+	case []string:
+		return element.StringType
+	case []bool:
+		return element.BoolType
+	case []time.Time:
+		return element.TimeType
+	}
+	return 0
+}
+
+// newSeries returns an empty series holding values of type dType.
+func newSeries(dType element.Dtype) AnySeries {
+	switch dType {
+	case element.IntType:
+		return NewIntSeries()
+	case element.FloatType:
+		return NewFloatSeries()
+	case element.StringType:
+		return NewStringSeries()
+	case element.BoolType:
+		return NewBoolSeries()
+	case element.TimeType:
+		return NewTimeSeries()
+	}
+	return nil
+}
+
+func (s Series[T]) Append(elements ...T) Series[T] {
+	changed := s.Clone()
+	changed.data = append(changed.data, elements...)
+	if changed.valid != nil {
+		bits := bitmapBuilder{}
+		bits.appendFrom(s.valid, s.Size())
+		for range elements {
+			bits.append(true)
+		}
+		changed.valid = bits.bitmap()
+	}
+	return changed
+}
+
+// AppendNull adds a null at the end of the series.
+func (s Series[T]) AppendNull() Series[T] {
+	var zero T
+	return s.Concat(Series[T]{data: []T{zero}, valid: bitmap{0}})
+}
+
+func (s Series[T]) Apply(oper func(T) T) Series[T] {
+	changed := Series[T]{valid: s.valid}
+	for x, entry := range s.data {
+		if !s.Valid(x) {
+			var zero T
+			changed.data = append(changed.data, zero)
+			continue
+		}
+		changed.data = append(changed.data, oper(entry))
+	}
+	return changed
+}
+
+func (s Series[T]) Clone() Series[T] {
+	cloned := Series[T]{}
+	cloned.data = append(cloned.data, s.data...)
+	cloned.valid = append(cloned.valid, s.valid...)
+	return cloned
+}
+
+func (s Series[T]) Size() int {
+	return len(s.data)
+}
+
+// Valid reports whether the value at index is present, as opposed to null.
+func (s Series[T]) Valid(index int) bool {
+	return s.valid.valid(index)
+}
+
+// Index returns the value at index, or the zero value when it is null.
+func (s Series[T]) Index(index int) T {
+	return s.data[index]
+}
+
+func (s Series[T]) Element(index int) element.Element {
+	return element.New(s.value(index))
+}
+
+func (s Series[T]) value(index int) interface{} {
+	if !s.Valid(index) {
+		return nil
+	}
+	return s.data[index]
+}
+
+func (s Series[T]) Concat(x Series[T]) Series[T] {
+	concat := NewSeries(append(s.Clone().data, x.data...)...)
+	if s.valid != nil || x.valid != nil {
+		bits := bitmapBuilder{}
+		bits.appendFrom(s.valid, s.Size())
+		bits.appendFrom(x.valid, x.Size())
+		concat.valid = bits.bitmap()
+	}
+	return concat
+}
+
+func (s Series[T]) concatAny(x AnySeries) AnySeries {
+	return s.Concat(x.(Series[T]))
+}
+
+func (s Series[T]) Subset(start int, end int) Series[T] {
+	positions := make([]int, 0, end-start)
+	for x := start; x < end; x++ {
+		positions = append(positions, x)
+	}
+	return s.take(positions)
+}
+
+// take returns the values at the given positions, in that order. A negative position gives a null.
+func (s Series[T]) take(positions []int) Series[T] {
+	var data []T
+	if len(positions) > 0 {
+		data = make([]T, len(positions))
+	}
+	bits := bitmapBuilder{}
+	for k, pos := range positions {
+		if pos < 0 || !s.Valid(pos) {
+			bits.append(false)
+			continue
+		}
+		data[k] = s.data[pos]
+		bits.append(true)
+	}
+	return Series[T]{data: data, valid: bits.bitmap()}
+}
+
+func (s Series[T]) takeAny(positions []int) AnySeries {
+	return s.take(positions)
+}
+
+func (s Series[T]) withValues(values []interface{}) AnySeries {
+	data := make([]T, len(values))
+	bits := bitmapBuilder{}
+	for k, val := range values {
+		data[k], _ = val.(T)
+		bits.append(val != nil)
+	}
+	return Series[T]{data: data, valid: bits.bitmap()}
+}
+
+func (s Series[T]) PassThrough(filter TruthFilter) Series[T] {
+	var positions []int
+	for index, pass := range filter {
+		if pass && index < s.Size() {
+			positions = append(positions, index)
+		}
+	}
+	return s.take(positions)
+}
+
+// IsNull returns a filter that is true where the values are null.
+func (s Series[T]) IsNull() (isNull TruthFilter) {
+	for x := range s.data {
+		isNull = append(isNull, !s.Valid(x))
+	}
+	return isNull
+}
+
+// NotNull returns a filter that is true where the values are present.
+func (s Series[T]) NotNull() TruthFilter {
+	return s.IsNull().Not()
+}
+
+// FillNA replaces the nulls with value.
+func (s Series[T]) FillNA(value T) Series[T] {
+	filled := Series[T]{data: make([]T, 0, s.Size())}
+	for x, entry := range s.data {
+		if !s.Valid(x) {
+			entry = value
+		}
+		filled.data = append(filled.data, entry)
+	}
+	return filled
+}
+
+func (s Series[T]) fillAny(value interface{}) (AnySeries, bool) {
+	val, ok := value.(T)
+	if !ok {
+		return nil, false
+	}
+	return s.FillNA(val), true
+}
+
+// DropNA removes the nulls.
+func (s Series[T]) DropNA() Series[T] {
+	return s.PassThrough(s.NotNull())
+}
+
+// Count returns the number of values that are not null.
+func (s Series[T]) Count() int {
+	return s.Size() - s.nullCount()
+}
+
+func (s Series[T]) nullCount() (count int) {
+	for x := range s.data {
+		if !s.Valid(x) {
+			count++
+		}
+	}
+	return count
+}
+
+func (s Series[T]) Filter(accept func(T) bool) (filter TruthFilter) {
+	for x, val := range s.data {
+		filter = append(filter, s.Valid(x) && accept(val))
+	}
+	return filter
+}
+
+func (s Series[T]) compare(x, y int) int {
+	if !s.Valid(x) || !s.Valid(y) {
+		return compareNulls(s.Valid(x), s.Valid(y))
+	}
+	switch data := any(s.data).(type) {
+	case []int64:
+		return cmp.Compare(data[x], data[y])
+	case []float64:
+		return cmp.Compare(data[x], data[y])
+	case []string:
+		return cmp.Compare(data[x], data[y])
+	case []bool:
+		return compareBools(data[x], data[y])
+	case []time.Time:
+		return data[x].Compare(data[y])
+	}
+	return 0
+}
@@ -0,0 +1,98 @@
+package godata
+
+import (
+	"github.com/stretchr/testify/require"
+	"github.com/tkhandel/go-data/element"
+	"testing"
+	"time"
+)
+
+func TestSeries_Append(t *testing.T) {
+	ints := NewIntSeries(1, 2)
+	require.Equal(t, NewIntSeries(1, 2, 3), ints.Append(3))
+	require.Equal(t, NewIntSeries(1, 2), ints)
+
+	floats := NewFloatSeries(1).AppendNull().Append(3)
+	require.Equal(t, TruthFilter{false, true, false}, floats.IsNull())
+}
+
+func TestSeries_Dtype(t *testing.T) {
+	require.Equal(t, element.IntType, NewIntSeries().Dtype())
+	require.Equal(t, element.FloatType, NewFloatSeries().Dtype())
+	require.Equal(t, element.StringType, NewStringSeries().Dtype())
+	require.Equal(t, element.Dtype(0), NewSeries[int32]().Dtype())
+}
+
+func TestSeries_Functions(t *testing.T) {
+	ints := NewIntSeries(3, 1, 2)
+	require.Equal(t, int64(6), Sum(ints))
+	require.Equal(t, float64(2), Avg(ints))
+	require.Equal(t, 1, Find(ints, 1))
+	require.Equal(t, -1, Find(ints, 5))
+	require.Equal(t, TruthFilter{true, false, false}, GreaterThan(ints, 2))
+	require.Equal(t, NewIntSeries(1, 2, 3), Sort(ints))
+
+	strs := NewStringSeries("b", "a").AppendNull()
+	pos, max := Max(strs)
+	require.Equal(t, 0, pos)
+	require.Equal(t, "b", max)
+	require.Equal(t, TruthFilter{false, true, false}, Equal(strs, "a"))
+	require.Equal(t, TruthFilter{true, false, false}, NotEqual(strs, "a"))
+}
+
+func TestDataFrame_SetColumn(t *testing.T) {
+	df := testDF()
+	changed, err := SetColumn(df, "col5", NewSeries[uint8](1, 2, 3))
+	require.IsType(t, Unsupported{}, err)
+	require.Equal(t, df, changed)
+
+	changed, err = SetColumn(df, "col5", NewIntSeries(1, 2, 3))
+	require.NoError(t, err)
+	val, err := ColumnOf[int64](changed, "col5")
+	require.NoError(t, err)
+	require.Equal(t, NewIntSeries(1, 2, 3), val)
+
+	_, err = ColumnOf[string](changed, "col5")
+	require.IsType(t, Unknown{}, err)
+
+	series, err := changed.Column("col5")
+	require.NoError(t, err)
+	require.Equal(t, element.IntType, series.Dtype())
+	require.Equal(t, 2, series.Element(1).MustInt())
+}
+
+func TestBoolAndTimeSeries(t *testing.T) {
+	flags := NewBoolSeries(true, false).AppendNull()
+	require.Equal(t, element.BoolType, flags.Dtype())
+	require.Equal(t, TruthFilter{true, false, false}, IsTrue(flags))
+	require.Equal(t, TruthFilter{false, true, false}, Equal(flags, false))
+	require.True(t, flags.Element(0).MustBool())
+
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+	noon := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	times := NewTimeSeries(noon.Add(-time.Hour), noon.In(paris), noon.Add(time.Hour))
+	require.Equal(t, element.TimeType, times.Dtype())
+	require.Equal(t, TruthFilter{true, false, false}, Before(times, noon))
+	require.Equal(t, TruthFilter{false, false, true}, After(times, noon))
+	require.Equal(t, TruthFilter{false, true, false}, EqualTime(times, noon))
+	require.Equal(t, paris, times.Element(1).MustTime().Location())
+
+	df, err := NewDataFrame(NewBoolColumn("flag"), NewTimeColumn("at"))
+	require.NoError(t, err)
+	df, err = df.SetBoolColumn("flag", flags)
+	require.NoError(t, err)
+	df, err = df.SetTimeColumn("at", times)
+	require.NoError(t, err)
+	filtered, err := df.Where(BoolCol("flag").IsTrue().Or(TimeCol("at").After(noon)))
+	require.NoError(t, err)
+	at, err := filtered.TimeColumn("at")
+	require.NoError(t, err)
+	require.Equal(t, []time.Time{noon.Add(-time.Hour), noon.Add(time.Hour)}, at.data)
+
+	sorted, err := df.SortBy(SortKey{Column: "flag"})
+	require.NoError(t, err)
+	sortedFlags, err := sorted.BoolColumn("flag")
+	require.NoError(t, err)
+	require.Equal(t, NewBoolSeries(false, true).AppendNull(), sortedFlags)
+}
@@ -0,0 +1,61 @@
+package godata
+
+import (
+	"github.com/tkhandel/go-data/log"
+	"sort"
+)
+
+// SortKey names a column to sort a frame on. The values are in ascending order unless Descending is set, and
+// the nulls come last unless NullsFirst is set.
+type SortKey struct {
+	Column     string
+	Descending bool
+	NullsFirst bool
+}
+
+// SortBy orders the rows on the first key, then on the next keys for the rows that are equal on the previous
+// ones. Rows that are equal on every key keep their relative order.
+func (df DataFrame) SortBy(keys ...SortKey) (DataFrame, error) {
+	compares := make([]func(x, y int) int, len(keys))
+	for k, key := range keys {
+		col, ok := df.columns[key.Column]
+		if !ok {
+			err := Unknown{What: "column", Value: key.Column}
+			log.Get().Error(err.Error())
+			return DataFrame{}, err
+		}
+
+		compares[k] = key.comparator(df.series[col.name].compare, func(x int) bool { return df.valid(col.name, x) })
+	}
+
+	positions := make([]int, df.NRows())
+	for x := range positions {
+		positions[x] = x
+	}
+	sort.SliceStable(positions, func(x, y int) bool {
+		for _, compare := range compares {
+			if c := compare(positions[x], positions[y]); c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+	return df.take(positions), nil
+}
+
+// comparator adapts the ascending, nulls last order of a series to the direction and null placement of the key.
+func (k SortKey) comparator(compare func(x, y int) int, valid func(x int) bool) func(x, y int) int {
+	return func(x, y int) int {
+		if !valid(x) || !valid(y) {
+			c := compareNulls(valid(x), valid(y))
+			if k.NullsFirst {
+				return -c
+			}
+			return c
+		}
+		if k.Descending {
+			return -compare(x, y)
+		}
+		return compare(x, y)
+	}
+}
@@ -0,0 +1,35 @@
+package godata
+
+import (
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestSeries_Argsort(t *testing.T) {
+	require.Equal(t, []int{1, 3, 0, 2}, Argsort(NewIntSeries(3, 1, 5, 1)))
+	require.Equal(t, []int{2, 0, 1}, Argsort(NewFloatSeries(2).AppendNull().Concat(NewFloatSeries(-1))))
+	require.Equal(t, []int{1, 2, 0}, Argsort(NewStringSeries("pear", "apple", "fig")))
+	require.Equal(t, NewStringSeries("apple", "fig", "pear"), Sort(NewStringSeries("pear", "apple", "fig")))
+}
+
+func TestDataFrame_SortBy(t *testing.T) {
+	c := CSV{HeadersPresent: true}
+	df, err := c.LoadCSV(strings.NewReader("region,qty,id\nEU,3,1\nUS,,2\nEU,5,3\nUS,4,4\nEU,3,5\n"))
+	require.NoError(t, err)
+
+	sorted, err := df.SortBy(SortKey{Column: "region"}, SortKey{Column: "qty", Descending: true})
+	require.NoError(t, err)
+	id, _ := sorted.IntColumn("id")
+	require.Equal(t, NewIntSeries(3, 1, 5, 4, 2), id)
+
+	sorted, err = df.SortBy(SortKey{Column: "qty", NullsFirst: true})
+	require.NoError(t, err)
+	id, _ = sorted.IntColumn("id")
+	require.Equal(t, NewIntSeries(2, 1, 5, 4, 3), id)
+	qty, _ := sorted.IntColumn("qty")
+	require.Equal(t, TruthFilter{true, false, false, false, false}, qty.IsNull())
+
+	_, err = df.SortBy(SortKey{Column: "foo"})
+	require.IsType(t, Unknown{}, err)
+}
@@ -0,0 +1,291 @@
+package godata
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/tkhandel/go-data/element"
+	"github.com/tkhandel/go-data/log"
+	"reflect"
+	"strings"
+)
+
+// FromRows reads the remaining rows of a query result into a frame and closes them. The type of each column is
+// chosen from its scan type when the driver reports one other than a string, and from its database type name
+// otherwise; columns of unknown types are read as strings. SQL NULLs are loaded as nulls.
+func FromRows(rows *sql.Rows) (DataFrame, error) {
+	defer rows.Close()
+	colTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return DataFrame{}, sqlError(err, "reading column types")
+	}
+
+	columns := make([]Column, len(colTypes))
+	for j, colType := range colTypes {
+		columns[j] = Column{name: colType.Name(), dType: sqlDtype(colType)}
+	}
+	df, err := NewDataFrame(columns...)
+	if err != nil {
+		return DataFrame{}, err
+	}
+
+	values := make([][]interface{}, len(columns))
+	dest := make([]interface{}, len(columns))
+	for j, col := range columns {
+		switch col.dType {
+		case element.IntType:
+			dest[j] = &sql.NullInt64{}
+		case element.FloatType:
+			dest[j] = &sql.NullFloat64{}
+		case element.BoolType:
+			dest[j] = &sql.NullBool{}
+		case element.TimeType:
+			dest[j] = &sql.NullTime{}
+		default:
+			dest[j] = &sql.NullString{}
+		}
+	}
+	for row := 0; rows.Next(); row++ {
+		if err := rows.Scan(dest...); err != nil {
+			return DataFrame{}, sqlError(err, fmt.Sprintf("scanning row %d", row))
+		}
+		for j := range dest {
+			values[j] = append(values[j], nullValue(dest[j]))
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return DataFrame{}, sqlError(err, "reading rows")
+	}
+
+	for j, col := range columns {
+		if df, err = df.setValues(col.name, col.dType, values[j]); err != nil {
+			return DataFrame{}, err
+		}
+	}
+	return df, nil
+}
+
+func sqlDtype(colType *sql.ColumnType) element.Dtype {
+	if scanType := colType.ScanType(); scanType != nil {
+		switch scanType {
+		case reflect.TypeOf(sql.NullInt64{}), reflect.TypeOf(sql.NullInt32{}), reflect.TypeOf(sql.NullInt16{}):
+			return element.IntType
+		case reflect.TypeOf(sql.NullFloat64{}):
+			return element.FloatType
+		case reflect.TypeOf(sql.NullBool{}):
+			return element.BoolType
+		case reflect.TypeOf(sql.NullTime{}), timeType:
+			return element.TimeType
+		}
+		// drivers such as SQLite's scan times and other values kept as text into strings, which the type name
+		// tells apart
+		if dType := fieldDtype(scanType); dType != 0 && dType != element.StringType {
+			return dType
+		}
+	}
+
+	// only the first word of the name counts, without parameters or signedness, e.g. DOUBLE for DOUBLE PRECISION
+	// and BIGINT for UNSIGNED BIGINT(20)
+	name, _, _ := strings.Cut(strings.ToUpper(colType.DatabaseTypeName()), "(")
+	for _, word := range strings.Fields(name) {
+		if word == "SIGNED" || word == "UNSIGNED" {
+			continue
+		}
+		if dType, ok := sqlTypeNames[word]; ok {
+			return dType
+		}
+		break
+	}
+	return element.StringType
+}
+
+// sqlTypeNames maps the common SQL type names to the type of the columns read from them.
+var sqlTypeNames = map[string]element.Dtype{
+	"INT":         element.IntType,
+	"INTEGER":     element.IntType,
+	"TINYINT":     element.IntType,
+	"SMALLINT":    element.IntType,
+	"MEDIUMINT":   element.IntType,
+	"BIGINT":      element.IntType,
+	"INT2":        element.IntType,
+	"INT4":        element.IntType,
+	"INT8":        element.IntType,
+	"SMALLSERIAL": element.IntType,
+	"SERIAL":      element.IntType,
+	"BIGSERIAL":   element.IntType,
+	"REAL":        element.FloatType,
+	"FLOAT":       element.FloatType,
+	"FLOAT4":      element.FloatType,
+	"FLOAT8":      element.FloatType,
+	"DOUBLE":      element.FloatType,
+	"NUMERIC":     element.FloatType,
+	"DECIMAL":     element.FloatType,
+	"BOOL":        element.BoolType,
+	"BOOLEAN":     element.BoolType,
+	"DATE":        element.TimeType,
+	"DATETIME":    element.TimeType,
+	"TIMESTAMP":   element.TimeType,
+	"TIMESTAMPTZ": element.TimeType,
+}
+
+// nullValue returns the value scanned into dest, or nil for a NULL.
+func nullValue(dest interface{}) interface{} {
+	switch val := dest.(type) {
+	case *sql.NullInt64:
+		if val.Valid {
+			return val.Int64
+		}
+	case *sql.NullFloat64:
+		if val.Valid {
+			return val.Float64
+		}
+	case *sql.NullBool:
+		if val.Valid {
+			return val.Bool
+		}
+	case *sql.NullTime:
+		if val.Valid {
+			return val.Time
+		}
+	case *sql.NullString:
+		if val.Valid {
+			return val.String
+		}
+	}
+	return nil
+}
+
+// Placeholder is the way a driver marks the arguments of a statement.
+type Placeholder int
+
+const (
+	// QuestionPlaceholder marks every argument with ?, as for SQLite and MySQL.
+	QuestionPlaceholder Placeholder = iota
+	// DollarPlaceholder numbers the arguments $1, $2 and so on, as for PostgreSQL.
+	DollarPlaceholder
+)
+
+// Quote is the way a database quotes the names of tables and columns.
+type Quote int
+
+const (
+	// DoubleQuote quotes names with double quotes, as in standard SQL, SQLite and PostgreSQL.
+	DoubleQuote Quote = iota
+	// BacktickQuote quotes names with backticks, as for MySQL.
+	BacktickQuote
+)
+
+type SQLOptions struct {
+	// BatchSize is the number of rows inserted by each statement. Zero or a negative value means 100.
+	BatchSize int
+	// CreateTable creates the table before inserting, with a column per column of the frame.
+	CreateTable bool
+	Placeholder Placeholder
+	Quote       Quote
+}
+
+// sqlTypes are the column types of the tables created by ToSQL.
+var sqlTypes = map[element.Dtype]string{
+	element.IntType:    "BIGINT",
+	element.FloatType:  "DOUBLE PRECISION",
+	element.StringType: "TEXT",
+	element.BoolType:   "BOOLEAN",
+	element.TimeType:   "TIMESTAMP",
+}
+
+// ToSQL inserts the rows of the frame into table within a single transaction, which is rolled back on the first
+// error. Every column of the frame is inserted into the column of the same name, and nulls as
+// NULL. Names are quoted as set by Quote.
+func (df DataFrame) ToSQL(db *sql.DB, table string, opts SQLOptions) error {
+	columns := df.ColumnNames()
+	if len(columns) == 0 {
+		err := Unsupported{What: "frame to insert", Value: "no columns"}
+		log.Get().Error(err.Error())
+		return err
+	}
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return sqlError(err, "beginning transaction")
+	}
+	if err := df.insertSQL(tx, table, columns, batchSize, opts); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return sqlError(err, "committing transaction")
+	}
+	return nil
+}
+
+func (df DataFrame) insertSQL(tx *sql.Tx, table string, columns []string, batchSize int, opts SQLOptions) error {
+	quoted := make([]string, len(columns))
+	for j, name := range columns {
+		quoted[j] = opts.Quote.quote(name)
+	}
+
+	if opts.CreateTable {
+		definitions := make([]string, len(columns))
+		for j, name := range columns {
+			definitions[j] = quoted[j] + " " + sqlTypes[df.columns[name].dType]
+		}
+		query := fmt.Sprintf("CREATE TABLE %s (%s)", opts.Quote.quote(table), strings.Join(definitions, ", "))
+		if _, err := tx.Exec(query); err != nil {
+			return sqlError(err, "creating table "+table)
+		}
+	}
+
+	insert := fmt.Sprintf("INSERT INTO %s (%s) VALUES ", opts.Quote.quote(table), strings.Join(quoted, ", "))
+	for start := 0; start < df.NRows(); start += batchSize {
+		end := min(start+batchSize, df.NRows())
+		var query strings.Builder
+		query.WriteString(insert)
+		args := make([]interface{}, 0, (end-start)*len(columns))
+		for i := start; i < end; i++ {
+			if i > start {
+				query.WriteString(", ")
+			}
+			query.WriteByte('(')
+			for j, name := range columns {
+				if j > 0 {
+					query.WriteString(", ")
+				}
+				var arg interface{}
+				if i < df.columnSize(name) {
+					arg = df.value(name, i)
+				}
+				args = append(args, arg)
+				query.WriteString(opts.Placeholder.mark(len(args)))
+			}
+			query.WriteByte(')')
+		}
+		if _, err := tx.Exec(query.String(), args...); err != nil {
+			return sqlError(err, fmt.Sprintf("inserting rows %d to %d into %s", start, end-1, table))
+		}
+	}
+	return nil
+}
+
+func (p Placeholder) mark(n int) string {
+	if p == DollarPlaceholder {
+		return fmt.Sprintf("$%d", n)
+	}
+	return "?"
+}
+
+func (q Quote) quote(name string) string {
+	if q == BacktickQuote {
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func sqlError(err error, what string) error {
+	sqlErr := ProcessingError{Err: errors.Wrap(err, what)}
+	log.Get().Error(sqlErr.Error())
+	return sqlErr
+}
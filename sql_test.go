@@ -0,0 +1,309 @@
+package godata
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"github.com/stretchr/testify/require"
+	"io"
+	_ "modernc.org/sqlite"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memDriver is an in-process database/sql driver keeping tables in memory. It understands only the statements
+// written by ToSQL, and SELECT * FROM a table. Inserting the value failOn fails.
+type memDriver struct {
+	mu        sync.Mutex
+	tables    map[string]*memTable
+	queries   []string
+	commits   int
+	rollbacks int
+	failOn    driver.Value
+}
+
+func (d *memDriver) reset() {
+	d.tables, d.queries, d.commits, d.rollbacks, d.failOn = make(map[string]*memTable), nil, 0, 0, nil
+}
+
+// memTable reports scanTypes as the scan types of its columns when they are set, and none otherwise.
+type memTable struct {
+	columns   []string
+	types     []string
+	scanTypes []reflect.Type
+	rows      [][]driver.Value
+}
+
+var (
+	memDB        = &memDriver{tables: make(map[string]*memTable)}
+	memCreate    = regexp.MustCompile(`^CREATE TABLE "(\w+)" \((.*)\)$`)
+	memInsert    = regexp.MustCompile(`^INSERT INTO "(\w+)" \((.*?)\) VALUES `)
+	memSelect    = regexp.MustCompile(`^SELECT \* FROM "(\w+)"$`)
+	memColumnDef = regexp.MustCompile(`"(\w+)" ([A-Z ]+)`)
+)
+
+func init() {
+	sql.Register("godata-mem", memDB)
+}
+
+func (d *memDriver) Open(string) (driver.Conn, error) {
+	return memConn{d}, nil
+}
+
+type memConn struct {
+	d *memDriver
+}
+
+func (c memConn) Prepare(query string) (driver.Stmt, error) {
+	return memStmt{d: c.d, query: query}, nil
+}
+
+func (c memConn) Close() error {
+	return nil
+}
+
+func (c memConn) Begin() (driver.Tx, error) {
+	c.d.mu.Lock()
+	defer c.d.mu.Unlock()
+	tables := make(map[string]*memTable, len(c.d.tables))
+	for name, table := range c.d.tables {
+		saved := *table
+		saved.rows = slices.Clip(saved.rows)
+		tables[name] = &saved
+	}
+	return memTx{d: c.d, tables: tables}, nil
+}
+
+// memTx restores the tables as they were when it began on a rollback.
+type memTx struct {
+	d      *memDriver
+	tables map[string]*memTable
+}
+
+func (t memTx) Commit() error {
+	t.d.mu.Lock()
+	defer t.d.mu.Unlock()
+	t.d.commits++
+	return nil
+}
+
+func (t memTx) Rollback() error {
+	t.d.mu.Lock()
+	defer t.d.mu.Unlock()
+	t.d.rollbacks++
+	t.d.tables = t.tables
+	return nil
+}
+
+type memStmt struct {
+	d     *memDriver
+	query string
+}
+
+func (s memStmt) Close() error {
+	return nil
+}
+
+func (s memStmt) NumInput() int {
+	return -1
+}
+
+func (s memStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	s.d.queries = append(s.d.queries, s.query)
+
+	if match := memCreate.FindStringSubmatch(s.query); match != nil {
+		table := &memTable{}
+		for _, def := range memColumnDef.FindAllStringSubmatch(match[2], -1) {
+			table.columns = append(table.columns, def[1])
+			table.types = append(table.types, def[2])
+		}
+		s.d.tables[match[1]] = table
+		return driver.RowsAffected(0), nil
+	}
+	if match := memInsert.FindStringSubmatch(s.query); match != nil {
+		table, ok := s.d.tables[match[1]]
+		if !ok || (s.d.failOn != nil && slices.Contains(args, s.d.failOn)) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		width := len(strings.Split(match[2], ", "))
+		for start := 0; start < len(args); start += width {
+			table.rows = append(table.rows, args[start:start+width])
+		}
+		return driver.RowsAffected(len(args) / width), nil
+	}
+	return nil, io.ErrUnexpectedEOF
+}
+
+func (s memStmt) Query([]driver.Value) (driver.Rows, error) {
+	match := memSelect.FindStringSubmatch(s.query)
+	if match == nil {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return &memRows{table: s.d.tables[match[1]]}, nil
+}
+
+type memRows struct {
+	table *memTable
+	next  int
+}
+
+func (r *memRows) Columns() []string {
+	return r.table.columns
+}
+
+func (r *memRows) ColumnTypeDatabaseTypeName(index int) string {
+	return r.table.types[index]
+}
+
+// ColumnTypeScanType leaves the type to the database type name, as many drivers do, unless the table has scan
+// types.
+func (r *memRows) ColumnTypeScanType(index int) reflect.Type {
+	if r.table.scanTypes != nil {
+		return r.table.scanTypes[index]
+	}
+	return reflect.TypeOf((*interface{})(nil)).Elem()
+}
+
+func (r *memRows) Close() error {
+	return nil
+}
+
+func (r *memRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.table.rows) {
+		return io.EOF
+	}
+	copy(dest, r.table.rows[r.next])
+	r.next++
+	return nil
+}
+
+func TestDataFrame_ToSQL_FromRows(t *testing.T) {
+	df, err := NewDataFrame(NewStringColumn("name"), NewIntColumn("qty"), NewFloatColumn("price"),
+		NewBoolColumn("fresh"), NewTimeColumn("picked"))
+	require.NoError(t, err)
+	picked := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	df, _ = df.SetStringColumn("name", NewStringSeries("apple", "pear", "plum"))
+	df, _ = df.SetIntColumn("qty", NewIntSeries(3, 4).AppendNull())
+	df, _ = df.SetFloatColumn("price", NewFloatSeries(1.5, 2, 0.25))
+	df, _ = df.SetBoolColumn("fresh", NewBoolSeries(true).AppendNull().Concat(NewBoolSeries(false)))
+	df, _ = df.SetTimeColumn("picked", NewTimeSeries(picked, picked, picked))
+
+	memDB.reset()
+	db, err := sql.Open("godata-mem", "")
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, df.ToSQL(db, "fruits", SQLOptions{BatchSize: 2, CreateTable: true}))
+	require.Equal(t, 1, memDB.commits)
+	require.Equal(t, []string{
+		`CREATE TABLE "fruits" ("name" TEXT, "qty" BIGINT, "price" DOUBLE PRECISION, "fresh" BOOLEAN, "picked" TIMESTAMP)`,
+		`INSERT INTO "fruits" ("name", "qty", "price", "fresh", "picked") VALUES (?, ?, ?, ?, ?), (?, ?, ?, ?, ?)`,
+		`INSERT INTO "fruits" ("name", "qty", "price", "fresh", "picked") VALUES (?, ?, ?, ?, ?)`,
+	}, memDB.queries)
+
+	rows, err := db.Query(`SELECT * FROM "fruits"`)
+	require.NoError(t, err)
+	loaded, err := FromRows(rows)
+	require.NoError(t, err)
+	require.Equal(t, df, loaded)
+}
+
+func TestDataFrame_ToSQL_Rollback(t *testing.T) {
+	df, err := NewDataFrame(NewStringColumn("name"))
+	require.NoError(t, err)
+	df, _ = df.SetStringColumn("name", NewStringSeries("apple", "pear", "plum"))
+
+	memDB.reset()
+	memDB.failOn = "plum"
+	db, err := sql.Open("godata-mem", "")
+	require.NoError(t, err)
+	defer db.Close()
+
+	err = df.ToSQL(db, "fruits", SQLOptions{BatchSize: 2, CreateTable: true})
+	require.IsType(t, ProcessingError{}, err)
+	require.Contains(t, err.Error(), "inserting rows 2 to 2 into fruits")
+	require.Len(t, memDB.queries, 3)
+	require.Equal(t, 0, memDB.commits)
+	require.Equal(t, 1, memDB.rollbacks)
+	require.Empty(t, memDB.tables)
+}
+
+func TestFromRows_Types(t *testing.T) {
+	memDB.reset()
+	memDB.tables["shapes"] = &memTable{
+		columns: []string{"id", "area", "at", "shape", "span", "sides"},
+		types:   []string{"UNSIGNED BIGINT(20)", "DOUBLE PRECISION", "TIMESTAMP WITH TIME ZONE", "POINT", "INTERVAL", "TEXT"},
+		rows: [][]driver.Value{
+			{int64(1), 2.5, time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC), "(1,2)", "1 day", int64(4)},
+		},
+	}
+	db, err := sql.Open("godata-mem", "")
+	require.NoError(t, err)
+	defer db.Close()
+
+	rows, err := db.Query(`SELECT * FROM "shapes"`)
+	require.NoError(t, err)
+	df, err := FromRows(rows)
+	require.NoError(t, err)
+	require.Equal(t, []Column{NewIntColumn("id"), NewFloatColumn("area"), NewTimeColumn("at"),
+		NewStringColumn("shape"), NewStringColumn("span"), NewStringColumn("sides")}, df.Columns())
+
+	nullInt := reflect.TypeOf(sql.NullInt64{})
+	memDB.tables["shapes"].scanTypes = []reflect.Type{nullInt, reflect.TypeOf(float64(0)), timeType,
+		reflect.TypeOf(""), reflect.TypeOf(sql.RawBytes{}), nullInt}
+	rows, err = db.Query(`SELECT * FROM "shapes"`)
+	require.NoError(t, err)
+	df, err = FromRows(rows)
+	require.NoError(t, err)
+	sides, err := df.IntColumn("sides")
+	require.NoError(t, err)
+	require.Equal(t, NewIntSeries(4), sides)
+}
+
+func TestPlaceholder(t *testing.T) {
+	require.Equal(t, "?", QuestionPlaceholder.mark(3))
+	require.Equal(t, "$3", DollarPlaceholder.mark(3))
+}
+
+func TestQuote(t *testing.T) {
+	require.Equal(t, `"my ""table"""`, DoubleQuote.quote(`my "table"`))
+	require.Equal(t, "`my ``table```", BacktickQuote.quote("my `table`"))
+}
+
+func TestDataFrame_ToSQL_SQLite(t *testing.T) {
+	picked := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	df, err := NewDataFrame(NewStringColumn("name"), NewIntColumn("qty"), NewFloatColumn("price"),
+		NewBoolColumn("fresh"), NewTimeColumn("picked"))
+	require.NoError(t, err)
+	df, err = df.SetStringColumn("name", NewStringSeries("apple", "pear").AppendNull())
+	require.NoError(t, err)
+	df, err = df.SetIntColumn("qty", NewIntSeries(3, -4).AppendNull())
+	require.NoError(t, err)
+	df, err = df.SetFloatColumn("price", NewFloatSeries(1.5, 2).AppendNull())
+	require.NoError(t, err)
+	df, err = df.SetBoolColumn("fresh", NewBoolSeries(true, false).AppendNull())
+	require.NoError(t, err)
+	df, err = df.SetTimeColumn("picked", NewTimeSeries(picked, picked.Add(time.Hour)).AppendNull())
+	require.NoError(t, err)
+
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	for _, quote := range []Quote{DoubleQuote, BacktickQuote} {
+		table := "fruit " + quote.quote("table")
+		require.NoError(t, df.ToSQL(db, table, SQLOptions{BatchSize: 2, CreateTable: true, Quote: quote}))
+		rows, err := db.Query("SELECT * FROM " + quote.quote(table))
+		require.NoError(t, err)
+		loaded, err := FromRows(rows)
+		require.NoError(t, err)
+		require.Equal(t, df, loaded)
+	}
+}
@@ -0,0 +1,209 @@
+package godata
+
+import (
+	"cmp"
+	"github.com/tkhandel/go-data/log"
+	"math"
+	"slices"
+)
+
+// The statistics below skip the nulls and give NaN when there are too few values to compute them.
+
+// Var returns the variance of the values, dividing the sum of squared deviations by their count minus ddof.
+// Use a ddof of 1 for the sample variance and 0 for the population variance.
+func Var[T Number](s Series[T], ddof int) float64 {
+	m := newMoments(s)
+	if m.count-ddof <= 0 {
+		return math.NaN()
+	}
+	return m.squares / float64(m.count-ddof)
+}
+
+// Std returns the standard deviation of the values, with the same ddof as Var.
+func Std[T Number](s Series[T], ddof int) float64 {
+	return math.Sqrt(Var(s, ddof))
+}
+
+func Median[T Number](s Series[T]) float64 {
+	return Quantile(s, 0.5)
+}
+
+// Quantile returns the value below which the fraction q of the values lie, interpolating linearly between the
+// two nearest values. q must be between 0 and 1.
+func Quantile[T Number](s Series[T], q float64) float64 {
+	if q < 0 || q > 1 {
+		return math.NaN()
+	}
+	return quantile(sortedValues(s), q)
+}
+
+// Mode returns the most frequent values in ascending order. It is empty when there is no value.
+func Mode[T cmp.Ordered](s Series[T]) Series[T] {
+	counts := make(map[T]int)
+	top := 0
+	for x, entry := range s.data {
+		if s.Valid(x) {
+			counts[entry]++
+			top = max(top, counts[entry])
+		}
+	}
+
+	var modes []T
+	for val, count := range counts {
+		if count == top {
+			modes = append(modes, val)
+		}
+	}
+	slices.Sort(modes)
+	return NewSeries(modes...)
+}
+
+// Skew returns the sample skewness of the values, corrected for bias. It needs at least three values.
+func Skew[T Number](s Series[T]) float64 {
+	m := newMoments(s)
+	if m.count < 3 {
+		return math.NaN()
+	}
+	if m.squares == 0 {
+		return 0
+	}
+	n := float64(m.count)
+	m2, m3 := m.squares/n, m.cubes/n
+	return m3 / math.Pow(m2, 1.5) * math.Sqrt(n*(n-1)) / (n - 2)
+}
+
+// Kurtosis returns the sample excess kurtosis of the values, corrected for bias. It needs at least four values.
+func Kurtosis[T Number](s Series[T]) float64 {
+	m := newMoments(s)
+	if m.count < 4 {
+		return math.NaN()
+	}
+	if m.squares == 0 {
+		return 0
+	}
+	n := float64(m.count)
+	m2, m4 := m.squares/n, m.fourths/n
+	return ((n+1)*(m4/(m2*m2)-3) + 6) * (n - 1) / ((n - 2) * (n - 3))
+}
+
+// moments holds the sums of the powers of the deviations of the values from their mean.
+type moments struct {
+	count                         int
+	mean, squares, cubes, fourths float64
+}
+
+func newMoments[T Number](s Series[T]) moments {
+	m := moments{}
+	for x, entry := range s.data {
+		if s.Valid(x) {
+			m.count++
+			m.mean += float64(entry)
+		}
+	}
+	if m.count == 0 {
+		return m
+	}
+	m.mean /= float64(m.count)
+	for x, entry := range s.data {
+		if s.Valid(x) {
+			dev := float64(entry) - m.mean
+			m.squares += dev * dev
+			m.cubes += dev * dev * dev
+			m.fourths += dev * dev * dev * dev
+		}
+	}
+	return m
+}
+
+func sortedValues[T Number](s Series[T]) []float64 {
+	values := make([]float64, 0, s.Size())
+	for x, entry := range s.data {
+		if s.Valid(x) {
+			values = append(values, float64(entry))
+		}
+	}
+	slices.Sort(values)
+	return values
+}
+
+func quantile(sorted []float64, q float64) float64 {
+	if len(sorted) == 0 {
+		return math.NaN()
+	}
+	pos := q * float64(len(sorted)-1)
+	lo := int(math.Floor(pos))
+	if lo == len(sorted)-1 {
+		return sorted[lo]
+	}
+	return sorted[lo] + (pos-float64(lo))*(sorted[lo+1]-sorted[lo])
+}
+
+// describeStatistics names the rows of the frame returned by Describe.
+var describeStatistics = []string{"count", "mean", "std", "min", "25%", "50%", "75%", "max"}
+
+// Describe summarises the int and float columns of the frame as float columns of the same name, with one row per
+// statistic named in the column statistic: count, mean, std, min, 25%, 50%, 75% and max. The nulls are skipped
+// and the standard deviation is the sample one.
+func (df DataFrame) Describe() (DataFrame, error) {
+	columns := []Column{NewStringColumn("statistic")}
+	stats := make(map[string][]float64)
+	for _, name := range df.ColumnNames() {
+		switch series := df.series[name].(type) {
+		case IntSeries:
+			stats[name] = describe(series)
+		case FloatSeries:
+			stats[name] = describe(series)
+		default:
+			continue
+		}
+		columns = append(columns, NewFloatColumn(name))
+	}
+
+	out, err := NewDataFrame(columns...)
+	if err != nil {
+		return DataFrame{}, err
+	}
+	if out, err = out.SetStringColumn("statistic", NewStringSeries(describeStatistics...)); err != nil {
+		log.Get().Error(err.Error())
+		return DataFrame{}, err
+	}
+	for name, values := range stats {
+		if out, err = out.SetFloatColumn(name, NewFloatSeries(values...)); err != nil {
+			log.Get().Error(err.Error())
+			return DataFrame{}, err
+		}
+	}
+	return out, nil
+}
+
+// describe gathers the statistics of Describe in a single pass over the values, sorting them once for the
+// quartiles.
+func describe[T Number](s Series[T]) []float64 {
+	values := make([]float64, 0, s.Size())
+	var mean, squares float64
+	for x, entry := range s.data {
+		if !s.Valid(x) {
+			continue
+		}
+		val := float64(entry)
+		values = append(values, val)
+		delta := val - mean
+		mean += delta / float64(len(values))
+		squares += delta * (val - mean)
+	}
+
+	n := len(values)
+	if n == 0 {
+		nan := math.NaN()
+		return []float64{0, nan, nan, nan, nan, nan, nan, nan}
+	}
+	std := math.NaN()
+	if n > 1 {
+		std = math.Sqrt(squares / float64(n-1))
+	}
+	slices.Sort(values)
+	return []float64{
+		float64(n), mean, std, values[0],
+		quantile(values, 0.25), quantile(values, 0.5), quantile(values, 0.75), values[n-1],
+	}
+}
@@ -0,0 +1,65 @@
+package godata
+
+import (
+	"github.com/stretchr/testify/require"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestStatistics(t *testing.T) {
+	s := NewFloatSeries(2, 4, 4, 4).AppendNull().Concat(NewFloatSeries(5, 5, 7, 9))
+	require.Equal(t, 8, s.Count())
+	require.InDelta(t, 4, Var(s, 0), 1e-12)
+	require.InDelta(t, 32.0/7, Var(s, 1), 1e-12)
+	require.InDelta(t, 2, Std(s, 0), 1e-12)
+	require.Equal(t, 4.5, Median(s))
+	require.Equal(t, 4.0, Quantile(s, 0.25))
+	require.Equal(t, 9.0, Quantile(s, 1))
+	require.True(t, math.IsNaN(Quantile(s, 1.5)))
+	require.Equal(t, NewFloatSeries(4), Mode(s))
+	require.InDelta(t, 0.8184875533567997, Skew(s), 1e-12)
+	require.InDelta(t, 0.940625, Kurtosis(s), 1e-12)
+
+	negative := NewIntSeries(-3, -1, -2, -1, -3)
+	_, min := Min(negative)
+	_, max := Max(negative)
+	require.Equal(t, int64(-3), min)
+	require.Equal(t, int64(-1), max)
+	require.Equal(t, NewIntSeries(-3, -1), Mode(negative))
+	require.Equal(t, 0.0, Skew(NewIntSeries(1, 1, 1)))
+
+	empty := NewIntSeries().AppendNull()
+	require.Equal(t, 0, empty.Count())
+	require.True(t, math.IsNaN(Var(empty, 1)))
+	require.True(t, math.IsNaN(Median(empty)))
+	require.True(t, math.IsNaN(Kurtosis(NewIntSeries(1, 2, 3))))
+	require.Equal(t, 0, Mode(empty).Size())
+}
+
+func TestDataFrame_Describe(t *testing.T) {
+	df, err := CSV{HeadersPresent: true}.LoadCSV(strings.NewReader(testCSV))
+	require.NoError(t, err)
+
+	described, err := df.Describe()
+	require.NoError(t, err)
+	require.ElementsMatch(t, []Column{
+		NewStringColumn("statistic"),
+		NewFloatColumn("qty"),
+		NewFloatColumn("price"),
+	}, described.Columns())
+
+	statistic, err := described.StringColumn("statistic")
+	require.NoError(t, err)
+	require.Equal(t, NewStringSeries("count", "mean", "std", "min", "25%", "50%", "75%", "max"), statistic)
+
+	qty, err := described.FloatColumn("qty")
+	require.NoError(t, err)
+	require.Equal(t, []float64{2, 3.5, math.Sqrt(0.5), 3, 3.25, 3.5, 3.75, 4}, qty.data)
+
+	price, err := described.FloatColumn("price")
+	require.NoError(t, err)
+	require.Equal(t, 3.0, price.Index(0))
+	require.InDelta(t, 1.25, price.Index(1), 1e-12)
+	require.Equal(t, 1.5, price.Index(5))
+}
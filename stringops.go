@@ -0,0 +1,148 @@
+package godata
+
+import (
+	"github.com/tkhandel/go-data/log"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// The string operations below leave the nulls null, and the tests among them give false for the nulls.
+
+func Contains(s StringSeries, substr string) TruthFilter {
+	return s.Filter(func(entry string) bool { return strings.Contains(entry, substr) })
+}
+
+func HasPrefix(s StringSeries, prefix string) TruthFilter {
+	return s.Filter(func(entry string) bool { return strings.HasPrefix(entry, prefix) })
+}
+
+func HasSuffix(s StringSeries, suffix string) TruthFilter {
+	return s.Filter(func(entry string) bool { return strings.HasSuffix(entry, suffix) })
+}
+
+// MatchRegexp returns a filter that is true where re matches part of the value.
+func MatchRegexp(s StringSeries, re *regexp.Regexp) TruthFilter {
+	return s.Filter(re.MatchString)
+}
+
+func ToUpper(s StringSeries) StringSeries {
+	return s.Apply(strings.ToUpper)
+}
+
+func ToLower(s StringSeries) StringSeries {
+	return s.Apply(strings.ToLower)
+}
+
+// Trim removes the leading and trailing characters contained in cutset, or white space when cutset is empty.
+func Trim(s StringSeries, cutset string) StringSeries {
+	if cutset == "" {
+		return s.Apply(strings.TrimSpace)
+	}
+	return s.Apply(func(entry string) string { return strings.Trim(entry, cutset) })
+}
+
+// Replace replaces every occurrence of old with new.
+func Replace(s StringSeries, old, new string) StringSeries {
+	return s.Apply(func(entry string) string { return strings.ReplaceAll(entry, old, new) })
+}
+
+// Len returns the number of characters of the values.
+func Len(s StringSeries) IntSeries {
+	return mapValues(s, func(entry string) (int64, bool) { return int64(utf8.RuneCountInString(entry)), true })
+}
+
+// Slice returns the characters from start up to end, both counted in characters and limited to the length of
+// the value.
+func Slice(s StringSeries, start, end int) StringSeries {
+	return s.Apply(func(entry string) string {
+		runes := []rune(entry)
+		lo, hi := min(max(start, 0), len(runes)), min(max(end, 0), len(runes))
+		if lo >= hi {
+			return ""
+		}
+		return string(runes[lo:hi])
+	})
+}
+
+// Split cuts the values around each instance of sep into a frame of string columns named "0", "1" and so on,
+// one per part. Values with fewer parts than the longest one are padded with nulls.
+func Split(s StringSeries, sep string) (DataFrame, error) {
+	parts := make([][]string, s.Size())
+	width := 0
+	for x, entry := range s.data {
+		if s.Valid(x) {
+			parts[x] = strings.Split(entry, sep)
+			width = max(width, len(parts[x]))
+		}
+	}
+
+	names := make([]string, width)
+	for j := range names {
+		names[j] = strconv.Itoa(j)
+	}
+	return stringsFrame(names, s.Size(), func(x, j int) (string, bool) {
+		if j < len(parts[x]) {
+			return parts[x][j], true
+		}
+		return "", false
+	})
+}
+
+// ExtractRegexp returns a frame with a string column per capture group of re, holding the text the group matched
+// in the first match within each value. The columns are named after the groups, or their position starting at
+// one for unnamed groups. Values that do not match, and groups that do not take part in the match, give nulls.
+func ExtractRegexp(s StringSeries, re *regexp.Regexp) (DataFrame, error) {
+	// SubexpNames returns the regexp's own slice, which must not change
+	names := slices.Clone(re.SubexpNames()[1:])
+	for j, name := range names {
+		if name == "" {
+			names[j] = strconv.Itoa(j + 1)
+		}
+	}
+
+	matches := make([][]int, s.Size())
+	for x, entry := range s.data {
+		if s.Valid(x) {
+			matches[x] = re.FindStringSubmatchIndex(entry)
+		}
+	}
+	return stringsFrame(names, s.Size(), func(x, j int) (string, bool) {
+		match := matches[x]
+		if match == nil || match[2*j+2] < 0 {
+			return "", false
+		}
+		return s.data[x][match[2*j+2]:match[2*j+3]], true
+	})
+}
+
+// stringsFrame returns a frame with a string column per name, holding rows values. value gives the value of
+// column j at row x, or false for a null.
+func stringsFrame(names []string, rows int, value func(x, j int) (string, bool)) (DataFrame, error) {
+	columns := make([]Column, len(names))
+	for j, name := range names {
+		columns[j] = NewStringColumn(name)
+	}
+	df, err := NewDataFrame(columns...)
+	if err != nil {
+		return DataFrame{}, err
+	}
+
+	for j, name := range names {
+		column := StringSeries{data: make([]string, rows)}
+		bits := bitmapBuilder{}
+		for x := range column.data {
+			val, ok := value(x, j)
+			column.data[x] = val
+			bits.append(ok)
+		}
+		column.valid = bits.bitmap()
+		if df, err = df.setSeries(name, column); err != nil {
+			log.Get().Error(err.Error())
+			return DataFrame{}, err
+		}
+	}
+	return df, nil
+}
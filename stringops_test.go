@@ -0,0 +1,67 @@
+package godata
+
+import (
+	"github.com/stretchr/testify/require"
+	"regexp"
+	"testing"
+)
+
+func TestStringOps(t *testing.T) {
+	s := NewStringSeries("  Apple pie ", "pear", "Plum").AppendNull()
+
+	require.Equal(t, TruthFilter{true, false, false, false}, Contains(s, "pie"))
+	require.Equal(t, TruthFilter{false, true, false, false}, HasPrefix(s, "pe"))
+	require.Equal(t, TruthFilter{false, false, true, false}, HasSuffix(s, "um"))
+	require.Equal(t, TruthFilter{true, false, true, false}, MatchRegexp(s, regexp.MustCompile(`^\s*[A-Z]`)))
+
+	require.Equal(t, NewStringSeries("  APPLE PIE ", "PEAR", "PLUM").AppendNull(), ToUpper(s))
+	require.Equal(t, NewStringSeries("  apple pie ", "pear", "plum").AppendNull(), ToLower(s))
+	require.Equal(t, NewStringSeries("Apple pie", "pear", "Plum").AppendNull(), Trim(s, ""))
+	require.Equal(t, NewStringSeries("  Apple pie ", "ea", "Plum").AppendNull(), Trim(s, "pr"))
+	require.Equal(t, NewStringSeries("__Apple_pie_", "pear", "Plum").AppendNull(), Replace(s, " ", "_"))
+	require.Equal(t, NewIntSeries(12, 4, 4).AppendNull(), Len(s))
+	require.Equal(t, NewStringSeries("pple", "ear", "lum").AppendNull(), Slice(Trim(s, ""), 1, 5))
+	require.Equal(t, NewIntSeries(3, 1), Len(NewStringSeries("été", "€")))
+}
+
+func TestSplit(t *testing.T) {
+	s := NewStringSeries("EU-DE-BER", "US").AppendNull()
+	parts, err := Split(s, "-")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []Column{
+		NewStringColumn("0"),
+		NewStringColumn("1"),
+		NewStringColumn("2"),
+	}, parts.Columns())
+
+	first, err := parts.StringColumn("0")
+	require.NoError(t, err)
+	require.Equal(t, NewStringSeries("EU", "US").AppendNull(), first)
+	last, err := parts.StringColumn("2")
+	require.NoError(t, err)
+	require.Equal(t, TruthFilter{false, true, true}, last.IsNull())
+	require.Equal(t, "BER", last.Index(0))
+}
+
+func TestExtractRegexp(t *testing.T) {
+	s := NewStringSeries("order 12 of 2020", "order 7", "refund").AppendNull()
+	re := regexp.MustCompile(`order (?P<id>\d+)( of (\d+))?`)
+	groups, err := ExtractRegexp(s, re)
+	require.NoError(t, err)
+	require.Equal(t, []string{"", "id", "", ""}, re.SubexpNames())
+	require.ElementsMatch(t, []Column{
+		NewStringColumn("id"),
+		NewStringColumn("2"),
+		NewStringColumn("3"),
+	}, groups.Columns())
+
+	id, err := groups.StringColumn("id")
+	require.NoError(t, err)
+	require.Equal(t, TruthFilter{false, false, true, true}, id.IsNull())
+	require.Equal(t, []string{"12", "7"}, id.data[:2])
+
+	year, err := groups.StringColumn("3")
+	require.NoError(t, err)
+	require.Equal(t, TruthFilter{false, true, true, true}, year.IsNull())
+	require.Equal(t, "2020", year.Index(0))
+}
@@ -0,0 +1,264 @@
+package godata
+
+import (
+	"github.com/pkg/errors"
+	"github.com/tkhandel/go-data/element"
+	"github.com/tkhandel/go-data/log"
+	"math"
+	"reflect"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// structField is an exported field of a struct mapped to a column, found by its index path through embedded
+// structs.
+type structField struct {
+	index   []int
+	field   string
+	column  string
+	dType   element.Dtype
+	pointer bool
+}
+
+// structFields maps the exported fields of struct type t to columns. A field is named after its godata tag,
+// or after the field itself without one, and is left out when the tag is "-". Ints and uints are stored as
+// ints, float32 and float64 as floats, along with strings, bools and time.Time; pointers to those hold nulls
+// as nil. The fields of untagged embedded structs are mapped as if they were fields of t.
+func structFields(t reflect.Type) ([]structField, error) {
+	var fields []structField
+	for k := 0; k < t.NumField(); k++ {
+		field := t.Field(k)
+		tag := field.Tag.Get("godata")
+		if tag == "-" {
+			continue
+		}
+		if field.Anonymous && tag == "" && field.Type.Kind() == reflect.Struct && field.Type != timeType {
+			embedded, err := structFields(field.Type)
+			if err != nil {
+				return nil, err
+			}
+			for _, mapped := range embedded {
+				mapped.index = append([]int{k}, mapped.index...)
+				fields = append(fields, mapped)
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		mapped := structField{index: field.Index, field: field.Name, column: tag}
+		if mapped.column == "" {
+			mapped.column = field.Name
+		}
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			mapped.pointer = true
+			fieldType = fieldType.Elem()
+		}
+		if mapped.dType = fieldDtype(fieldType); mapped.dType == 0 {
+			return nil, Unsupported{What: "type of field " + field.Name, Value: field.Type.String()}
+		}
+		fields = append(fields, mapped)
+	}
+	return fields, nil
+}
+
+func fieldDtype(t reflect.Type) element.Dtype {
+	if t == timeType {
+		return element.TimeType
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return element.IntType
+	case reflect.Float32, reflect.Float64:
+		return element.FloatType
+	case reflect.String:
+		return element.StringType
+	case reflect.Bool:
+		return element.BoolType
+	}
+	return 0
+}
+
+// FromStructs builds a frame with a row per element of slice, which is a slice or array of structs or of
+// pointers to structs, and a column per field as described by structFields. Nil pointers give rows of nulls.
+func FromStructs(slice interface{}) (DataFrame, error) {
+	rows := reflect.ValueOf(slice)
+	if !rows.IsValid() {
+		return DataFrame{}, structsError(Unsupported{What: "type to build a frame from", Value: "nil"})
+	}
+	if rows.Kind() != reflect.Slice && rows.Kind() != reflect.Array {
+		return DataFrame{}, structsError(Unsupported{What: "type to build a frame from", Value: rows.Type().String()})
+	}
+	elemType := rows.Type().Elem()
+	if elemType.Kind() == reflect.Pointer {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return DataFrame{}, structsError(Unsupported{What: "type to build a frame from", Value: rows.Type().String()})
+	}
+	fields, err := structFields(elemType)
+	if err != nil {
+		return DataFrame{}, structsError(err)
+	}
+
+	columns := make([]Column, len(fields))
+	for k, field := range fields {
+		columns[k] = Column{name: field.column, dType: field.dType}
+	}
+	df, err := NewDataFrame(columns...)
+	if err != nil {
+		return DataFrame{}, err
+	}
+
+	for _, field := range fields {
+		values := make([]interface{}, rows.Len())
+		for i := range values {
+			row := rows.Index(i)
+			if row.Kind() == reflect.Pointer {
+				if row.IsNil() {
+					continue
+				}
+				row = row.Elem()
+			}
+			if values[i], err = fieldValue(row.FieldByIndex(field.index)); err != nil {
+				return DataFrame{}, structsError(ProcessingError{
+					Err: errors.Wrapf(err, "reading field %s of row %d", field.field, i),
+				})
+			}
+		}
+		if df, err = df.setValues(field.column, field.dType, values); err != nil {
+			return DataFrame{}, err
+		}
+	}
+	return df, nil
+}
+
+// fieldValue returns the value of a field as the Go type backing its column, or nil for a nil pointer. Unsigned
+// values beyond the range of int64 give an error.
+func fieldValue(val reflect.Value) (interface{}, error) {
+	if val.Kind() == reflect.Pointer {
+		if val.IsNil() {
+			return nil, nil
+		}
+		val = val.Elem()
+	}
+	if val.Type() == timeType {
+		return val.Interface(), nil
+	}
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return val.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if val.Uint() > math.MaxInt64 {
+			return nil, errors.Errorf("%d overflows int64", val.Uint())
+		}
+		return int64(val.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return val.Float(), nil
+	case reflect.String:
+		return val.String(), nil
+	case reflect.Bool:
+		return val.Bool(), nil
+	}
+	return nil, nil
+}
+
+// ToStructs fills the slice dst points to with a struct per row, or a pointer to one, setting each field
+// described by structFields from the column of the same name. Every field needs a column of the matching type.
+// Nulls leave pointer fields nil and other fields at their zero value.
+func (df DataFrame) ToStructs(dst interface{}) error {
+	ptr := reflect.ValueOf(dst)
+	if !ptr.IsValid() {
+		return structsError(Unsupported{What: "destination for rows", Value: "nil"})
+	}
+	if ptr.Kind() != reflect.Pointer || ptr.Elem().Kind() != reflect.Slice {
+		return structsError(Unsupported{What: "destination for rows", Value: reflect.TypeOf(dst).String()})
+	}
+	sliceType := ptr.Elem().Type()
+	elemType := sliceType.Elem()
+	pointers := elemType.Kind() == reflect.Pointer
+	if pointers {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return structsError(Unsupported{What: "destination for rows", Value: reflect.TypeOf(dst).String()})
+	}
+	fields, err := structFields(elemType)
+	if err != nil {
+		return structsError(err)
+	}
+	for _, field := range fields {
+		col, ok := df.columns[field.column]
+		if !ok {
+			return structsError(Unknown{What: "column for field " + field.field, Value: field.column})
+		}
+		if col.dType != field.dType {
+			return structsError(Unsupported{
+				What:  "type of field " + field.field + " for " + col.dType.String() + " column " + col.name,
+				Value: elemType.FieldByIndex(field.index).Type.String(),
+			})
+		}
+	}
+
+	rows := reflect.MakeSlice(sliceType, df.NRows(), df.NRows())
+	for i := 0; i < rows.Len(); i++ {
+		row := rows.Index(i)
+		if pointers {
+			row.Set(reflect.New(elemType))
+			row = row.Elem()
+		}
+		for _, field := range fields {
+			value := df.value(field.column, i)
+			if value == nil {
+				continue
+			}
+			if err := setField(row.FieldByIndex(field.index), value); err != nil {
+				return structsError(ProcessingError{
+					Err: errors.Wrapf(err, "setting field %s from row %d of column %s", field.field, i, field.column),
+				})
+			}
+		}
+	}
+	ptr.Elem().Set(rows)
+	return nil
+}
+
+// setField stores value, of the Go type backing a column, in a field mapped to a column of that type.
+func setField(field reflect.Value, value interface{}) error {
+	if field.Kind() == reflect.Pointer {
+		field.Set(reflect.New(field.Type().Elem()))
+		field = field.Elem()
+	}
+	switch val := value.(type) {
+	case int64:
+		if field.CanInt() {
+			if field.OverflowInt(val) {
+				return errors.Errorf("%d overflows %s", val, field.Type())
+			}
+			field.SetInt(val)
+			return nil
+		}
+		if val < 0 || field.OverflowUint(uint64(val)) {
+			return errors.Errorf("%d overflows %s", val, field.Type())
+		}
+		field.SetUint(uint64(val))
+	case float64:
+		field.SetFloat(val)
+	case string:
+		field.SetString(val)
+	case bool:
+		field.SetBool(val)
+	case time.Time:
+		field.Set(reflect.ValueOf(val))
+	}
+	return nil
+}
+
+func structsError(err error) error {
+	log.Get().Error(err.Error())
+	return err
+}
@@ -0,0 +1,125 @@
+package godata
+
+import (
+	"github.com/stretchr/testify/require"
+	"math"
+	"testing"
+	"time"
+)
+
+type testFruit struct {
+	Name     string `godata:"name"`
+	Qty      uint8  `godata:"qty"`
+	Price    *float64
+	Fresh    bool      `godata:"fresh"`
+	Picked   time.Time `godata:"picked"`
+	Internal []string  `godata:"-"`
+	note     string
+}
+
+func TestFromStructs(t *testing.T) {
+	price := 1.5
+	picked := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	fruits := []*testFruit{
+		{Name: "apple", Qty: 3, Price: &price, Fresh: true, Picked: picked, note: "skipped"},
+		{Name: "pear", Qty: 4},
+		nil,
+	}
+
+	df, err := FromStructs(fruits)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []Column{
+		NewStringColumn("name"),
+		NewIntColumn("qty"),
+		NewFloatColumn("Price"),
+		NewBoolColumn("fresh"),
+		NewTimeColumn("picked"),
+	}, df.Columns())
+
+	qty, err := df.IntColumn("qty")
+	require.NoError(t, err)
+	require.Equal(t, NewIntSeries(3, 4).AppendNull(), qty)
+	prices, err := df.FloatColumn("Price")
+	require.NoError(t, err)
+	require.Equal(t, TruthFilter{false, true, true}, prices.IsNull())
+
+	var back []testFruit
+	require.NoError(t, df.ToStructs(&back))
+	require.Equal(t, []testFruit{
+		{Name: "apple", Qty: 3, Price: &price, Fresh: true, Picked: picked},
+		{Name: "pear", Qty: 4},
+		{},
+	}, back)
+
+	_, err = FromStructs(testFruit{})
+	require.IsType(t, Unsupported{}, err)
+	_, err = FromStructs([]struct{ Tags []string }{})
+	require.IsType(t, Unsupported{}, err)
+	_, err = FromStructs(nil)
+	require.IsType(t, Unsupported{}, err)
+
+	_, err = FromStructs([]struct{ ID uint64 }{{ID: math.MaxInt64}, {ID: 1 << 63}})
+	require.IsType(t, ProcessingError{}, err)
+	require.Contains(t, err.Error(), "reading field ID of row 1")
+}
+
+type testBase struct {
+	ID   int64 `godata:"id"`
+	Note string
+}
+
+func TestStructs_Embedded(t *testing.T) {
+	type row struct {
+		testBase
+		X int
+	}
+	df, err := FromStructs([]row{{testBase{1, "a"}, 10}, {testBase{2, "b"}, 20}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"id", "Note", "X"}, df.ColumnNames())
+	ids, err := df.IntColumn("id")
+	require.NoError(t, err)
+	require.Equal(t, int64(2), ids.Index(1))
+
+	var rows []row
+	require.NoError(t, df.ToStructs(&rows))
+	require.Equal(t, []row{{testBase{1, "a"}, 10}, {testBase{2, "b"}, 20}}, rows)
+}
+
+func TestDataFrame_ToStructs_Errors(t *testing.T) {
+	df := testDF()
+
+	var missing []struct {
+		Value string `godata:"foo"`
+	}
+	err := df.ToStructs(&missing)
+	require.IsType(t, Unknown{}, err)
+	require.Contains(t, err.Error(), "foo")
+
+	var mistyped []struct {
+		Value string `godata:"col3"`
+	}
+	err = df.ToStructs(&mistyped)
+	require.IsType(t, Unsupported{}, err)
+	require.Contains(t, err.Error(), "field Value for Integer column col3: string")
+
+	var overflow []struct {
+		Value int8 `godata:"col3"`
+	}
+	df, err = df.SetIntColumn(col3, NewIntSeries(1, 2, 300))
+	require.NoError(t, err)
+	err = df.ToStructs(&overflow)
+	require.IsType(t, ProcessingError{}, err)
+	require.Contains(t, err.Error(), "row 2 of column col3")
+
+	var pointers []*struct {
+		Value *int64 `godata:"col3"`
+	}
+	require.NoError(t, df.ToStructs(&pointers))
+	require.Equal(t, int64(300), *pointers[2].Value)
+
+	require.IsType(t, Unsupported{}, df.ToStructs(missing))
+	err = df.ToStructs(nil)
+	require.IsType(t, Unsupported{}, err)
+	require.Contains(t, err.Error(), "nil")
+	require.IsType(t, Unsupported{}, df.ToStructs((*[]struct{ Value int64 })(nil)))
+}
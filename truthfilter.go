@@ -1,5 +1,7 @@
 package godata
 
+import "github.com/tkhandel/go-data/log"
+
 type TruthFilter []bool
 
 func (t TruthFilter) Not() (not TruthFilter) {
@@ -9,16 +11,59 @@ func (t TruthFilter) Not() (not TruthFilter) {
 	return not
 }
 
-func (t TruthFilter) And(addFilter TruthFilter) (and TruthFilter) {
+// And, Or and Xor combine the values at the same position of the two filters, which must have the same length.
+func (t TruthFilter) And(addFilter TruthFilter) (TruthFilter, error) {
+	return t.combine(addFilter, func(x, y bool) bool { return x && y })
+}
+
+func (t TruthFilter) Or(addFilter TruthFilter) (TruthFilter, error) {
+	return t.combine(addFilter, func(x, y bool) bool { return x || y })
+}
+
+func (t TruthFilter) Xor(addFilter TruthFilter) (TruthFilter, error) {
+	return t.combine(addFilter, func(x, y bool) bool { return x != y })
+}
+
+func (t TruthFilter) combine(addFilter TruthFilter, op func(x, y bool) bool) (TruthFilter, error) {
+	if len(t) != len(addFilter) {
+		err := LengthMismatch{What: "filter", Expected: len(t), Actual: len(addFilter)}
+		log.Get().Error(err.Error())
+		return nil, err
+	}
+	var combined TruthFilter
 	for i := range t {
-		and = append(and, t[i] && addFilter[i])
+		combined = append(combined, op(t[i], addFilter[i]))
 	}
-	return and
+	return combined, nil
 }
 
-func (t TruthFilter) Or(addFilter TruthFilter) (or TruthFilter) {
-	for i := range t {
-		or = append(or, t[i] || addFilter[i])
+// Count returns the number of true values.
+func (t TruthFilter) Count() (count int) {
+	for _, val := range t {
+		if val {
+			count++
+		}
+	}
+	return count
+}
+
+// Any reports whether a value is true.
+func (t TruthFilter) Any() bool {
+	return t.Count() > 0
+}
+
+// All reports whether every value is true. It is true for an empty filter.
+func (t TruthFilter) All() bool {
+	return t.Count() == len(t)
+}
+
+// Indices returns the positions of the true values.
+func (t TruthFilter) Indices() []int {
+	indices := make([]int, 0, t.Count())
+	for i, val := range t {
+		if val {
+			indices = append(indices, i)
+		}
 	}
-	return or
+	return indices
 }
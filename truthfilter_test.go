@@ -0,0 +1,41 @@
+package godata
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestTruthFilter(t *testing.T) {
+	x := TruthFilter{true, true, false, false}
+	y := TruthFilter{true, false, true, false}
+
+	and, err := x.And(y)
+	require.NoError(t, err)
+	require.Equal(t, TruthFilter{true, false, false, false}, and)
+	or, err := x.Or(y)
+	require.NoError(t, err)
+	require.Equal(t, TruthFilter{true, true, true, false}, or)
+	xor, err := x.Xor(y)
+	require.NoError(t, err)
+	require.Equal(t, TruthFilter{false, true, true, false}, xor)
+
+	_, err = x.And(TruthFilter{true})
+	require.IsType(t, LengthMismatch{}, err)
+	_, err = x.Or(nil)
+	require.IsType(t, LengthMismatch{}, err)
+
+	require.Equal(t, 2, x.Count())
+	require.Equal(t, []int{0, 1}, x.Indices())
+	require.True(t, x.Any())
+	require.False(t, x.All())
+	require.True(t, TruthFilter{true}.All())
+	require.False(t, TruthFilter{false}.Any())
+	require.True(t, TruthFilter{}.All())
+}
+
+func TestCondition_Xor(t *testing.T) {
+	df := testDF()
+	filtered, err := df.Where(IntCol(col3).GreaterThan(4).Xor(IntCol(col3).GreaterThan(6)))
+	require.NoError(t, err)
+	require.Equal(t, 2, filtered.NRows())
+}